@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/cawabunga/terra.go/rpcclient"
+
 	cosmostypes "github.com/cosmos/cosmos-sdk/types"
 	cosmosauth "github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/pkg/errors"
@@ -81,16 +83,18 @@ type CreateTxOptions struct {
 	GasPrices     cosmostypes.DecCoins
 	Sequence      *uint64
 	Memo          string
+
+	// BlockGasLimitRPC, if set, is the Tendermint RPC the block's max gas is read from,
+	// and the tx is rejected before broadcast if its gas exceeds it.
+	BlockGasLimitRPC rpcclient.Client
+
+	// BuildBeforeHeight, if set, makes CreateTx fail with ErrBuildHeightReached once the chain
+	// has reached it. It is a check before building only: a v0.39 StdTx can't carry a timeout
+	// height, so the tx doesn't expire and can be included at any later height.
+	BuildBeforeHeight uint64
 }
 
 func (a *keyedAccount) CreateTx(ctx context.Context, opts CreateTxOptions) (terraauth.StdSignMsg, error) {
-	if opts.GasAdjustment == 0 {
-		opts.GasAdjustment = DefaultGasAdjustment
-	}
-	if opts.GasPrices == nil || len(opts.GasPrices) == 0 {
-		opts.GasPrices = DefaultGasPrice
-	}
-
 	if err := a.Update(ctx); err != nil {
 		return terraauth.StdSignMsg{}, errors.Wrap(err, "update account")
 	}
@@ -113,15 +117,49 @@ func (a *keyedAccount) CreateTx(ctx context.Context, opts CreateTxOptions) (terr
 			sequence = *opts.Sequence
 		}
 	}
+	return createSignMsg(ctx, a.client, a.chainId, a.GetAccountNumber(), sequence, a.GetAddress(), opts)
+}
+
+func createSignMsg(
+	ctx context.Context,
+	client Client,
+	chainId string,
+	accountNumber, sequence uint64,
+	from cosmostypes.AccAddress,
+	opts CreateTxOptions,
+) (terraauth.StdSignMsg, error) {
+	if opts.GasAdjustment == 0 {
+		opts.GasAdjustment = DefaultGasAdjustment
+	}
+	if opts.GasPrices == nil || len(opts.GasPrices) == 0 {
+		opts.GasPrices = DefaultGasPrice
+	}
+
+	if opts.BuildBeforeHeight != 0 {
+		_, block, err := client.Tendermint().GetBlockByHeight(ctx, nil)
+		if err != nil {
+			return terraauth.StdSignMsg{}, errors.Wrap(err, "fetch latest block")
+		}
+		if uint64(block.Height) >= opts.BuildBeforeHeight {
+			return terraauth.StdSignMsg{}, errors.Wrapf(
+				ErrBuildHeightReached,
+				"latest height %d >= build before height %d",
+				block.Height, opts.BuildBeforeHeight,
+			)
+		}
+	}
 
-	var fee terraauth.StdFee
+	var (
+		fee terraauth.StdFee
+		err error
+	)
 	if opts.Fee == nil {
-		fee, err = a.client.Transaction().EstimateFee(
+		fee, err = client.Transaction().EstimateFee(
 			ctx,
-			a.GetAddress().String(),
+			from.String(),
 			terraauth.StdSignMsg{
-				ChainID:       a.chainId,
-				AccountNumber: a.GetAccountNumber(),
+				ChainID:       chainId,
+				AccountNumber: accountNumber,
 				Sequence:      sequence,
 				Msgs:          opts.Msgs,
 				Memo:          opts.Memo,
@@ -136,9 +174,23 @@ func (a *keyedAccount) CreateTx(ctx context.Context, opts CreateTxOptions) (terr
 		fee = *opts.Fee
 	}
 
+	if opts.BlockGasLimitRPC != nil {
+		params, err := opts.BlockGasLimitRPC.ConsensusParams(ctx)
+		if err != nil {
+			return terraauth.StdSignMsg{}, errors.Wrap(err, "fetch consensus params")
+		}
+		if maxGas := params.Block.MaxGas; maxGas > 0 && fee.Gas > uint64(maxGas) {
+			return terraauth.StdSignMsg{}, errors.Wrapf(
+				ErrGasExceedsBlockLimit,
+				"gas %d > max gas %d, split the messages into multiple transactions",
+				fee.Gas, maxGas,
+			)
+		}
+	}
+
 	return terraauth.StdSignMsg{
-		ChainID:       a.chainId,
-		AccountNumber: a.GetAccountNumber(),
+		ChainID:       chainId,
+		AccountNumber: accountNumber,
 		Sequence:      sequence,
 		Fee:           fee,
 		Msgs:          opts.Msgs,
@@ -158,3 +210,13 @@ func (a *keyedAccount) CreateAndSignTx(ctx context.Context, opts CreateTxOptions
 	}
 	return signedTx, signMsg, nil
 }
+
+// HeightFromNow returns the height that is blocks ahead of the current head, e.g. for
+// CreateTxOptions.BuildBeforeHeight or WaitMode.WithTimeoutHeight.
+func HeightFromNow(ctx context.Context, client Client, blocks uint64) (uint64, error) {
+	_, block, err := client.Tendermint().GetBlockByHeight(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "fetch latest block")
+	}
+	return uint64(block.Height) + blocks, nil
+}
@@ -0,0 +1,82 @@
+package terra
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/rpcclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestCreateTxBlockGasLimit(t *testing.T) {
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"block_height":"100","consensus_params":{
+			"block":{"max_bytes":"22020096","max_gas":"1000000","time_iota_ms":"1000"},
+			"evidence":{"max_age_num_blocks":"100000","max_age_duration":"172800000000000"},
+			"validator":{"pub_key_types":["ed25519"]}
+		}}}`))
+	}))
+	defer rpc.Close()
+	cdc := MakeCodec()
+	// the fee is given, so nothing is asked of the LCD
+	client := NewClient(httpclient.New(cdc, "http://127.0.0.1:1"))
+	from := NewRawKey("a96e62ed3955e65be32703f12d87b6b5cf26039ecfa948dc5107a495418e5330").AccAddress()
+
+	msg := terrabank.NewMsgSend(from, from, cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 1000000)))
+	opts := CreateTxOptions{
+		Msgs:             []cosmostypes.Msg{msg},
+		Fee:              &terraauth.StdFee{Gas: 1500000},
+		BlockGasLimitRPC: rpcclient.New(httpclient.New(cdc, rpc.URL)),
+	}
+	_, err := createSignMsg(context.Background(), client, "bombay-12", 10, 3, from, opts)
+	assert.True(t, errors.Is(err, ErrGasExceedsBlockLimit))
+
+	opts.Fee = &terraauth.StdFee{Gas: 1000000}
+	signMsg, err := createSignMsg(context.Background(), client, "bombay-12", 10, 3, from, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000000), signMsg.Fee.Gas)
+}
+
+func TestCreateTxBuildBeforeHeight(t *testing.T) {
+	// no fee estimation route, so a reached height must be caught before estimating
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blocks/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"block_id":{},"block":{"header":{"height":"100"}}}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	client := NewClient(httpclient.New(MakeCodec(), server.URL))
+	from := NewRawKey("a96e62ed3955e65be32703f12d87b6b5cf26039ecfa948dc5107a495418e5330").AccAddress()
+
+	height, err := HeightFromNow(ctx, client, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(120), height)
+
+	msg := terrabank.NewMsgSend(from, from, cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 1000000)))
+	_, err = createSignMsg(ctx, client, "bombay-12", 10, 3, from, CreateTxOptions{
+		Msgs:              []cosmostypes.Msg{msg},
+		BuildBeforeHeight: 100,
+	})
+	assert.True(t, errors.Is(err, ErrBuildHeightReached))
+
+	signMsg, err := createSignMsg(ctx, client, "bombay-12", 10, 3, from, CreateTxOptions{
+		Msgs:              []cosmostypes.Msg{msg},
+		Fee:               &terraauth.StdFee{Gas: 200000},
+		BuildBeforeHeight: height,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(200000), signMsg.Fee.Gas)
+}
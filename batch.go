@@ -0,0 +1,48 @@
+package terra
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// OfflineBatchBuilder signs a batch of txs without touching the network, e.g. on a cold signer.
+// Every tx takes the next sequence starting from StartSequence, so the batch must be broadcast in order.
+type OfflineBatchBuilder struct {
+	Codec         *codec.Codec
+	Key           Key
+	ChainId       string
+	AccountNumber uint64
+	StartSequence uint64
+}
+
+// Build signs one tx per options and returns them in the ExportSignedTx format.
+// Fee is required on each options since it can't be estimated offline.
+func (b OfflineBatchBuilder) Build(txOpts ...CreateTxOptions) ([][]byte, error) {
+	signedTxs := make([][]byte, 0, len(txOpts))
+	for index, opts := range txOpts {
+		if opts.Fee == nil {
+			return nil, errors.Wrapf(ErrMissingOfflineFee, "tx #%d", index)
+		}
+
+		signMsg := terraauth.StdSignMsg{
+			ChainID:       b.ChainId,
+			AccountNumber: b.AccountNumber,
+			Sequence:      b.StartSequence + uint64(index),
+			Fee:           *opts.Fee,
+			Msgs:          opts.Msgs,
+			Memo:          opts.Memo,
+		}
+		tx, err := b.Key.SignTx(signMsg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "sign tx #%d", index)
+		}
+
+		bz, err := ExportSignedTx(b.Codec, tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "export tx #%d", index)
+		}
+		signedTxs = append(signedTxs, bz)
+	}
+	return signedTxs, nil
+}
@@ -0,0 +1,58 @@
+package terra
+
+import (
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestOfflineBatchBuilder(t *testing.T) {
+	cdc := MakeCodec()
+	key := NewRawKey("a96e62ed3955e65be32703f12d87b6b5cf26039ecfa948dc5107a495418e5330")
+
+	builder := OfflineBatchBuilder{
+		Codec:         cdc,
+		Key:           key,
+		ChainId:       "bombay-12",
+		AccountNumber: 7,
+		StartSequence: 40,
+	}
+
+	fee := terraauth.StdFee{
+		Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 30000)),
+		Gas:    200000,
+	}
+	opts := CreateTxOptions{
+		Msgs: []cosmostypes.Msg{terrabank.MsgSend{
+			FromAddress: key.AccAddress(),
+			ToAddress:   cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+			Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+		}},
+		Fee: &fee,
+	}
+
+	batch, err := builder.Build(opts, opts, opts)
+	assert.NoError(t, err)
+	assert.Len(t, batch, 3)
+
+	for index, bz := range batch {
+		tx, err := ImportSignedTx(cdc, bz)
+		assert.NoError(t, err)
+
+		signMsg := terraauth.StdSignMsg{
+			ChainID:       "bombay-12",
+			AccountNumber: 7,
+			Sequence:      40 + uint64(index),
+			Fee:           fee,
+			Msgs:          opts.Msgs,
+		}
+		assert.True(t, key.PubKey().VerifyBytes(signMsg.Bytes(), tx.Signatures[0].Signature))
+	}
+
+	_, err = builder.Build(CreateTxOptions{Msgs: opts.Msgs})
+	assert.Error(t, err)
+}
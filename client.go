@@ -3,45 +3,101 @@ package terra
 import (
 	"github.com/cawabunga/terra.go/httpclient"
 	"github.com/cawabunga/terra.go/service"
+	"github.com/cawabunga/terra.go/types"
 )
 
 var _ Client = (*terraClient)(nil)
 
 //go:generate mockgen -destination ../../test/mocks/terra/client.go . Client
 type Client interface {
+	AddressPrefix() types.AddressPrefix
+
 	Auth() service.AuthService
+	Authz() service.AuthzService
 	Bank() service.BankService
+	Distribution() service.DistributionService
+	Feegrant() service.FeegrantService
+	FeeMarket() service.FeeMarketService
 	Contract() service.ContractService
+	Governance() service.GovernanceService
+	IBC() service.IBCService
+	Mint() service.MintService
+	Oracle() service.OracleService
+	Slashing() service.SlashingService
+	Staking() service.StakingService
 	Treasury() service.TreasuryService
 	Tendermint() service.TendermintService
 	Transaction() service.TransactionService
 }
 
 type terraClient struct {
-	client httpclient.Client
-
-	auth        service.AuthService
-	bank        service.BankService
-	contract    service.ContractService
-	treasury    service.TreasuryService
-	tendermint  service.TendermintService
-	transaction service.TransactionService
+	client        httpclient.Client
+	addressPrefix types.AddressPrefix
+
+	auth         service.AuthService
+	authz        service.AuthzService
+	bank         service.BankService
+	distribution service.DistributionService
+	feegrant     service.FeegrantService
+	feeMarket    service.FeeMarketService
+	contract     service.ContractService
+	governance   service.GovernanceService
+	ibc          service.IBCService
+	mint         service.MintService
+	oracle       service.OracleService
+	slashing     service.SlashingService
+	staking      service.StakingService
+	treasury     service.TreasuryService
+	tendermint   service.TendermintService
+	transaction  service.TransactionService
 }
 
-func (c terraClient) Auth() service.AuthService               { return c.auth }
-func (c terraClient) Bank() service.BankService               { return c.bank }
-func (c terraClient) Contract() service.ContractService       { return c.contract }
-func (c terraClient) Treasury() service.TreasuryService       { return c.treasury }
-func (c terraClient) Tendermint() service.TendermintService   { return c.tendermint }
-func (c terraClient) Transaction() service.TransactionService { return c.transaction }
+func (c terraClient) AddressPrefix() types.AddressPrefix { return c.addressPrefix }
+
+func (c terraClient) Auth() service.AuthService                 { return c.auth }
+func (c terraClient) Authz() service.AuthzService               { return c.authz }
+func (c terraClient) Bank() service.BankService                 { return c.bank }
+func (c terraClient) Distribution() service.DistributionService { return c.distribution }
+func (c terraClient) Feegrant() service.FeegrantService         { return c.feegrant }
+func (c terraClient) FeeMarket() service.FeeMarketService       { return c.feeMarket }
+func (c terraClient) Contract() service.ContractService         { return c.contract }
+func (c terraClient) Governance() service.GovernanceService     { return c.governance }
+func (c terraClient) IBC() service.IBCService                   { return c.ibc }
+func (c terraClient) Mint() service.MintService                 { return c.mint }
+func (c terraClient) Oracle() service.OracleService             { return c.oracle }
+func (c terraClient) Slashing() service.SlashingService         { return c.slashing }
+func (c terraClient) Staking() service.StakingService           { return c.staking }
+func (c terraClient) Treasury() service.TreasuryService         { return c.treasury }
+func (c terraClient) Tendermint() service.TendermintService     { return c.tendermint }
+func (c terraClient) Transaction() service.TransactionService   { return c.transaction }
+
+func NewClient(client httpclient.Client, opts ...Option) Client {
+	options := clientOptions{addressPrefix: types.TerraAddressPrefix}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.addressPrefix != types.TerraAddressPrefix {
+		client = service.WithAddressPrefix(client, options.addressPrefix)
+	}
 
-func NewClient(client httpclient.Client) Client {
 	return terraClient{
-		auth:        service.NewAuthService(client),
-		bank:        service.NewBankService(client),
-		contract:    service.NewContractService(client),
-		treasury:    service.NewTreasuryService(client),
-		tendermint:  service.NewTendermintService(client),
-		transaction: service.NewTransactionService(client),
+		client:        client,
+		addressPrefix: options.addressPrefix,
+		auth:          service.NewAuthService(client),
+		authz:         service.NewAuthzService(client),
+		bank:          service.NewBankService(client),
+		distribution:  service.NewDistributionService(client),
+		feegrant:      service.NewFeegrantService(client),
+		feeMarket:     service.NewFeeMarketService(client),
+		contract:      service.NewContractService(client),
+		governance:    service.NewGovernanceService(client),
+		ibc:           service.NewIBCService(client),
+		mint:          service.NewMintService(client),
+		oracle:        service.NewOracleService(client),
+		slashing:      service.NewSlashingService(client),
+		staking:       service.NewStakingService(client),
+		treasury:      service.NewTreasuryService(client),
+		tendermint:    service.NewTendermintService(client),
+		transaction:   service.NewTransactionService(client, options.transaction...),
 	}
 }
@@ -0,0 +1,42 @@
+package terra
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient/httpclienttest"
+
+	"github.com/tj/assert"
+)
+
+// newFakeClient serves the given raw bodies to GET requests by path and 404s anything else.
+func newFakeClient(routes map[string]string) Client {
+	fake := httpclienttest.NewFakeClient(MakeCodec())
+	for path, body := range routes {
+		fake.Stub(http.MethodGet, path, body)
+	}
+	return NewClient(fake)
+}
+
+func TestNewClientAddressPrefix(t *testing.T) {
+	fake := httpclienttest.NewFakeClient(MakeCodec())
+	client := NewClient(fake, WithAddressPrefix("kava", "kavavaloper", "kavavalcons"))
+	key := NewRawKey("a96e62ed3955e65be32703f12d87b6b5cf26039ecfa948dc5107a495418e5330")
+
+	address, err := KeyAccAddress(client, key)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(address, "kava1"))
+	valoper, err := KeyValAddress(client, key)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(valoper, "kavavaloper1"))
+
+	exists, err := client.Auth().Exists(context.Background(), key.AccAddress())
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	fake.AssertCalled(t, http.MethodGet, "/auth/accounts/"+address)
+
+	_, _ = client.Staking().GetValidator(context.Background(), key.ValAddress())
+	fake.AssertCalled(t, http.MethodGet, "/staking/validators/"+valoper)
+}
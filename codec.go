@@ -37,6 +37,7 @@ func MakeCodec() *codec.Codec {
 	cdc.RegisterConcrete(types.MsgExecuteContract{}, "wasm/MsgExecuteContract", nil)
 	cdc.RegisterConcrete(wasm.MsgMigrateContract{}, "wasm/MsgMigrateContract", nil)
 	cdc.RegisterConcrete(wasm.MsgUpdateContractOwner{}, "wasm/MsgUpdateContractOwner", nil)
+	cdc.RegisterConcrete(types.MsgCancelUnbondingDelegation{}, "cosmos-sdk/MsgCancelUnbondingDelegation", nil)
 
 	module.NewBasicManager(
 		genutil.AppModuleBasic{},
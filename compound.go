@@ -0,0 +1,91 @@
+package terra
+
+import (
+	"context"
+
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosdistr "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/pkg/errors"
+)
+
+// BuildCompoundMsgs returns withdraw-reward messages for the given validators, followed by
+// delegate messages restaking each validator's pending bond denom reward to it.
+// The exact reward is only known after withdrawal, so the rewards query is used as the estimate
+// and truncated down so the delegation never exceeds what was withdrawn.
+// If validators is empty, every validator with pending rewards is compounded.
+func BuildCompoundMsgs(
+	ctx context.Context,
+	client Client,
+	delegator cosmostypes.AccAddress,
+	validators []cosmostypes.ValAddress,
+) ([]cosmostypes.Msg, error) {
+	params, err := client.Staking().GetParams(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch staking params")
+	}
+
+	rewards, err := client.Distribution().GetDelegatorRewards(ctx, delegator)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch delegator rewards")
+	}
+
+	rewardByValidator := make(map[string]cosmostypes.DecCoins, len(rewards.Rewards))
+	for _, reward := range rewards.Rewards {
+		rewardByValidator[reward.ValidatorAddress.String()] = reward.Reward
+	}
+	if len(validators) == 0 {
+		for _, reward := range rewards.Rewards {
+			validators = append(validators, reward.ValidatorAddress)
+		}
+	}
+
+	var (
+		withdraws []cosmostypes.Msg
+		delegates []cosmostypes.Msg
+	)
+	for _, validator := range validators {
+		withdraws = append(withdraws, cosmosdistr.NewMsgWithdrawDelegatorReward(delegator, validator))
+
+		amount := rewardByValidator[validator.String()].AmountOf(params.BondDenom).TruncateInt()
+		if !amount.IsPositive() {
+			continue
+		}
+		delegates = append(delegates, stakingtypes.NewMsgDelegate(
+			delegator,
+			validator,
+			cosmostypes.NewCoin(params.BondDenom, amount),
+		))
+	}
+	return append(withdraws, delegates...), nil
+}
+
+// CompoundRewards withdraws the rewards from the given validators and restakes them in one tx.
+func CompoundRewards(
+	ctx context.Context,
+	account Account,
+	validators []cosmostypes.ValAddress,
+	mode types.BroadcastMode,
+	opts CreateTxOptions,
+) (cosmostypes.TxResponse, error) {
+	client := account.GetClient()
+
+	msgs, err := BuildCompoundMsgs(ctx, client, account.GetAddress(), validators)
+	if err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrap(err, "build compound msgs")
+	}
+	opts.Msgs = append(opts.Msgs, msgs...)
+
+	tx, _, err := account.CreateAndSignTx(ctx, opts)
+	if err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrap(err, "create and sign tx")
+	}
+
+	resp, err := client.Transaction().BroadcastTx(ctx, tx, mode)
+	if err != nil {
+		return resp, errors.Wrap(err, "broadcast tx")
+	}
+	return resp, nil
+}
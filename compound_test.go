@@ -0,0 +1,41 @@
+package terra
+
+import (
+	"context"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosdistr "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tj/assert"
+)
+
+func TestBuildCompoundMsgs(t *testing.T) {
+	delegator := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	rich := cosmostypes.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	dust := cosmostypes.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	routes := map[string]string{
+		"/staking/parameters": `{"height":"100","result":{
+			"unbonding_time":"1814400000000000","max_validators":100,"max_entries":7,
+			"historical_entries":0,"bond_denom":"uluna"
+		}}`,
+		"/distribution/delegators/" + delegator.String() + "/rewards": `{"height":"100","result":{
+			"rewards":[
+				{"validator_address":"` + rich.String() + `","reward":[{"denom":"uluna","amount":"1500.750000000000000000"},{"denom":"uusd","amount":"20.000000000000000000"}]},
+				{"validator_address":"` + dust.String() + `","reward":[{"denom":"uluna","amount":"0.300000000000000000"}]}
+			],
+			"total":[{"denom":"uluna","amount":"1501.050000000000000000"},{"denom":"uusd","amount":"20.000000000000000000"}]
+		}}`,
+	}
+	client := newFakeClient(routes)
+	msgs, err := BuildCompoundMsgs(context.Background(), client, delegator, []cosmostypes.ValAddress{rich, dust})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []cosmostypes.Msg{
+		cosmosdistr.NewMsgWithdrawDelegatorReward(delegator, rich),
+		cosmosdistr.NewMsgWithdrawDelegatorReward(delegator, dust),
+		stakingtypes.NewMsgDelegate(delegator, rich, cosmostypes.NewInt64Coin("uluna", 1500)),
+	}, msgs)
+}
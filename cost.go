@@ -0,0 +1,99 @@
+package terra
+
+import (
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraassets "github.com/terra-project/core/types/assets"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	terramarket "github.com/terra-project/core/x/market"
+	terrawasm "github.com/terra-project/core/x/wasm"
+)
+
+// SendCost is what a tx costs its fee payer. Fee.Amount is GasFee plus Tax, the way the LCD
+// estimates it.
+type SendCost struct {
+	Fee    terraauth.StdFee
+	GasFee cosmostypes.Coins
+	Tax    cosmostypes.Coins
+}
+
+// PreviewCost computes the fee and stability tax of msgs from the given gas, gas prices, tax rate and
+// the tax caps of the denoms the msgs transfer, without any request, e.g. for an air-gapped machine
+// with the rates fetched beforehand. The tax is charged on the coins msgs send the same way the ante
+// handler does, capped per denom and not on Luna; a taxed denom missing from taxCaps fails with
+// ErrMissingTaxCap.
+func PreviewCost(
+	gas uint64,
+	gasPrices cosmostypes.DecCoins,
+	taxRate cosmostypes.Dec,
+	taxCaps map[string]cosmostypes.Int,
+	msgs []cosmostypes.Msg,
+) (SendCost, error) {
+	gasFee := cosmostypes.NewCoins()
+	for _, price := range gasPrices {
+		amount := price.Amount.MulInt64(int64(gas)).Ceil().RoundInt()
+		gasFee = gasFee.Add(cosmostypes.NewCoin(price.Denom, amount))
+	}
+
+	tax := cosmostypes.NewCoins()
+	for _, principal := range taxedCoins(msgs) {
+		for _, coin := range principal {
+			due, err := computeTax(coin, taxRate, taxCaps)
+			if err != nil {
+				return SendCost{}, err
+			}
+			if due.IsPositive() {
+				tax = tax.Add(cosmostypes.NewCoin(coin.Denom, due))
+			}
+		}
+	}
+
+	return SendCost{
+		Fee:    terraauth.StdFee{Amount: gasFee.Add(tax...), Gas: gas},
+		GasFee: gasFee,
+		Tax:    tax,
+	}, nil
+}
+
+// taxedCoins picks the coins of msgs the ante handler charges tax on.
+func taxedCoins(msgs []cosmostypes.Msg) []cosmostypes.Coins {
+	var coins []cosmostypes.Coins
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case terrabank.MsgSend:
+			coins = append(coins, msg.Amount)
+		case terrabank.MsgMultiSend:
+			for _, input := range msg.Inputs {
+				coins = append(coins, input.Coins)
+			}
+		case terramarket.MsgSwapSend:
+			coins = append(coins, cosmostypes.NewCoins(msg.OfferCoin))
+		case terrawasm.MsgInstantiateContract:
+			coins = append(coins, msg.InitCoins)
+		case terrawasm.MsgExecuteContract:
+			coins = append(coins, msg.Coins)
+		case types.MsgExecuteContract:
+			coins = append(coins, msg.Coins)
+		}
+	}
+	return coins
+}
+
+func computeTax(coin cosmostypes.Coin, taxRate cosmostypes.Dec, taxCaps map[string]cosmostypes.Int) (cosmostypes.Int, error) {
+	if coin.Denom == terraassets.MicroLunaDenom || taxRate.IsZero() {
+		return cosmostypes.ZeroInt(), nil
+	}
+	taxCap, ok := taxCaps[coin.Denom]
+	if !ok {
+		return cosmostypes.Int{}, errors.Wrapf(ErrMissingTaxCap, "%s", coin.Denom)
+	}
+
+	due := coin.Amount.ToDec().Mul(taxRate).TruncateInt()
+	if due.GT(taxCap) {
+		due = taxCap
+	}
+	return due, nil
+}
@@ -0,0 +1,61 @@
+package terra
+
+import (
+	"context"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestPreviewCost(t *testing.T) {
+	ctx := context.Background()
+	routes := map[string]string{
+		"/treasury/tax_rate":     `{"height":"100","result":"0.005000000000000000"}`,
+		"/treasury/tax_cap/uusd": `{"height":"100","result":"1000000"}`,
+		"/treasury/tax_cap/ukrw": `{"height":"100","result":"1000000000"}`,
+	}
+	client := newFakeClient(routes)
+
+	from := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	sent := cosmostypes.NewCoins(
+		cosmostypes.NewInt64Coin("uusd", 500000000), // capped
+		cosmostypes.NewInt64Coin("ukrw", 12345678),
+	)
+	msgs := []cosmostypes.Msg{
+		terrabank.NewMsgSend(from, to, sent),
+		terrabank.NewMsgSend(from, to, cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 1000000))),
+	}
+
+	// the same inputs the online path reads from the LCD
+	rate, err := client.Treasury().GetTaxRate(ctx)
+	assert.NoError(t, err)
+	caps := map[string]cosmostypes.Int{}
+	expectedTax := cosmostypes.NewCoins()
+	for _, coin := range sent {
+		taxCap, err := client.Treasury().GetTaxCap(ctx, coin.Denom)
+		assert.NoError(t, err)
+		caps[coin.Denom] = taxCap.TaxCap
+
+		tax, err := client.Treasury().CalculateTax(ctx, coin)
+		assert.NoError(t, err)
+		expectedTax = expectedTax.Add(cosmostypes.NewCoin(coin.Denom, tax))
+	}
+
+	gasPrices := cosmostypes.NewDecCoins(cosmostypes.NewDecCoinFromDec("uusd", cosmostypes.MustNewDecFromStr("0.15")))
+	cost, err := PreviewCost(100001, gasPrices, rate.TaxRate, caps, msgs)
+	assert.NoError(t, err)
+	assert.Equal(t, "61728ukrw,1000000uusd", expectedTax.String())
+	assert.Equal(t, expectedTax.String(), cost.Tax.String())
+	assert.Equal(t, "15001uusd", cost.GasFee.String())
+	assert.Equal(t, "61728ukrw,1015001uusd", cost.Fee.Amount.String())
+	assert.Equal(t, uint64(100001), cost.Fee.Gas)
+
+	delete(caps, "ukrw")
+	_, err = PreviewCost(100001, gasPrices, rate.TaxRate, caps, msgs)
+	assert.Equal(t, ErrMissingTaxCap, errors.Cause(err))
+}
@@ -0,0 +1,97 @@
+package terra
+
+import (
+	"strings"
+	"sync"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraassets "github.com/terra-project/core/types/assets"
+	terrabank "github.com/terra-project/core/x/bank"
+	terramarket "github.com/terra-project/core/x/market"
+)
+
+// DenomUnit maps a display denom like LUNA to the micro denom the chain counts in.
+type DenomUnit struct {
+	Denom    string
+	Decimals int64
+}
+
+var (
+	denomUnitsMu sync.RWMutex
+	denomUnits   = map[string]DenomUnit{
+		"LUNA": {Denom: terraassets.MicroLunaDenom, Decimals: 6},
+		"UST":  {Denom: terraassets.MicroUSDDenom, Decimals: 6},
+		"KRT":  {Denom: terraassets.MicroKRWDenom, Decimals: 6},
+		"SDT":  {Denom: terraassets.MicroSDRDenom, Decimals: 6},
+		"MNT":  {Denom: terraassets.MicroMNTDenom, Decimals: 6},
+	}
+)
+
+// RegisterDenomUnit adds or replaces the display denom, matched case-insensitively.
+func RegisterDenomUnit(display string, unit DenomUnit) {
+	denomUnitsMu.Lock()
+	defer denomUnitsMu.Unlock()
+
+	denomUnits[strings.ToUpper(display)] = unit
+}
+
+// ParseHumanCoin converts an amount in a registered display denom, e.g. "10" LUNA, to micro units.
+// A registered micro denom is accepted too, with an integer amount.
+func ParseHumanCoin(amount, denom string) (cosmostypes.Coin, error) {
+	unit, err := lookupDenomUnit(denom)
+	if err != nil {
+		return cosmostypes.Coin{}, err
+	}
+
+	dec, err := cosmostypes.NewDecFromStr(strings.TrimSpace(amount))
+	if err != nil {
+		return cosmostypes.Coin{}, errors.Wrapf(err, "amount %q", amount)
+	}
+	if !dec.IsPositive() {
+		return cosmostypes.Coin{}, errors.Errorf("amount %q is not positive", amount)
+	}
+
+	micro := dec.MulInt(cosmostypes.NewIntWithDecimal(1, int(unit.Decimals)))
+	if !micro.IsInteger() {
+		return cosmostypes.Coin{}, errors.Errorf("amount %q has more than %d decimals", amount, unit.Decimals)
+	}
+	return cosmostypes.NewCoin(unit.Denom, micro.TruncateInt()), nil
+}
+
+func lookupDenomUnit(denom string) (DenomUnit, error) {
+	denomUnitsMu.RLock()
+	defer denomUnitsMu.RUnlock()
+
+	if unit, ok := denomUnits[strings.ToUpper(denom)]; ok {
+		return unit, nil
+	}
+	for _, unit := range denomUnits {
+		if unit.Denom == denom {
+			return DenomUnit{Denom: denom}, nil
+		}
+	}
+	return DenomUnit{}, errors.Wrapf(ErrUnknownDenom, "%q", denom)
+}
+
+// NewSend builds a MsgSend of amount given in a human readable denom, see ParseHumanCoin.
+func NewSend(from, to cosmostypes.AccAddress, amount, denom string) (terrabank.MsgSend, error) {
+	coin, err := ParseHumanCoin(amount, denom)
+	if err != nil {
+		return terrabank.MsgSend{}, err
+	}
+	return terrabank.NewMsgSend(from, to, cosmostypes.NewCoins(coin)), nil
+}
+
+// NewSwap builds a MsgSwap offering amount of denom for askDenom, both either display or micro denoms.
+func NewSwap(trader cosmostypes.AccAddress, amount, denom, askDenom string) (terramarket.MsgSwap, error) {
+	coin, err := ParseHumanCoin(amount, denom)
+	if err != nil {
+		return terramarket.MsgSwap{}, err
+	}
+	ask, err := lookupDenomUnit(askDenom)
+	if err != nil {
+		return terramarket.MsgSwap{}, err
+	}
+	return terramarket.NewMsgSwap(trader, coin, ask.Denom), nil
+}
@@ -0,0 +1,42 @@
+package terra
+
+import (
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tj/assert"
+)
+
+func TestNewSendHumanAmount(t *testing.T) {
+	from := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	msg, err := NewSend(from, to, "10", "LUNA")
+	assert.NoError(t, err)
+	assert.Equal(t, "10000000uluna", msg.Amount.String())
+
+	msg, err = NewSend(from, to, "0.5", "ust")
+	assert.NoError(t, err)
+	assert.Equal(t, "500000uusd", msg.Amount.String())
+
+	// micro denoms are taken as they are
+	msg, err = NewSend(from, to, "2500", "uluna")
+	assert.NoError(t, err)
+	assert.Equal(t, "2500uluna", msg.Amount.String())
+
+	_, err = NewSend(from, to, "10", "DOGE")
+	assert.True(t, errors.Is(err, ErrUnknownDenom))
+
+	_, err = NewSend(from, to, "ten", "LUNA")
+	assert.Error(t, err)
+
+	_, err = NewSend(from, to, "0.0000001", "LUNA")
+	assert.Error(t, err)
+
+	swap, err := NewSwap(from, "1.5", "LUNA", "UST")
+	assert.NoError(t, err)
+	assert.Equal(t, "1500000uluna", swap.OfferCoin.String())
+	assert.Equal(t, "uusd", swap.AskDenom)
+}
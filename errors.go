@@ -0,0 +1,16 @@
+package terra
+
+import "github.com/pkg/errors"
+
+var (
+	ErrGasExceedsBlockLimit = errors.New("gas exceeds block max gas")
+	ErrInvalidSignature     = errors.New("signature doesn't match the sign bytes")
+	ErrMissingOfflineFee    = errors.New("fee must be given to build a tx offline")
+	ErrNoExchangeRate       = errors.New("no oracle exchange rate for denom")
+	ErrMissingSigner        = errors.New("no key for a required signer")
+	ErrBuildHeightReached   = errors.New("chain has reached the height to build the tx before")
+	ErrBroadcastPending     = errors.New("tx was already broadcast but isn't on chain yet")
+	ErrQueueClosed          = errors.New("broadcast queue is closed")
+	ErrUnknownDenom         = errors.New("denom isn't registered")
+	ErrMissingTaxCap        = errors.New("no tax cap given for denom")
+)
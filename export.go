@@ -0,0 +1,25 @@
+package terra
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// ExportSignedTx encodes tx in the format `terrad tx broadcast` reads: {"type":"core/StdTx","value":{...}}.
+func ExportSignedTx(cdc *codec.Codec, tx terraauth.StdTx) ([]byte, error) {
+	bz, err := cdc.MarshalJSON(tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal tx")
+	}
+	return bz, nil
+}
+
+// ImportSignedTx decodes a tx file produced by ExportSignedTx or `terrad tx sign`.
+func ImportSignedTx(cdc *codec.Codec, bz []byte) (terraauth.StdTx, error) {
+	var tx terraauth.StdTx
+	if err := cdc.UnmarshalJSON(bz, &tx); err != nil {
+		return terraauth.StdTx{}, errors.Wrap(err, "unmarshal tx")
+	}
+	return tx, nil
+}
@@ -0,0 +1,70 @@
+package terra
+
+import (
+	"encoding/json"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestExportSignedTx(t *testing.T) {
+	cdc := MakeCodec()
+
+	key := NewRawKey("a96e62ed3955e65be32703f12d87b6b5cf26039ecfa948dc5107a495418e5330")
+	signMsg := terraauth.StdSignMsg{
+		ChainID:       "bombay-12",
+		AccountNumber: 1,
+		Sequence:      2,
+		Fee: terraauth.StdFee{
+			Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 30000)),
+			Gas:    200000,
+		},
+		Msgs: []cosmostypes.Msg{terrabank.MsgSend{
+			FromAddress: key.AccAddress(),
+			ToAddress:   cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+			Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+		}},
+		Memo: "export",
+	}
+	tx, err := key.SignTx(signMsg)
+	assert.NoError(t, err)
+
+	exported, err := ExportSignedTx(cdc, tx)
+	assert.NoError(t, err)
+
+	var file struct {
+		Type  string `json:"type"`
+		Value struct {
+			Msg []struct {
+				Type string `json:"type"`
+			} `json:"msg"`
+			Fee        json.RawMessage   `json:"fee"`
+			Signatures []json.RawMessage `json:"signatures"`
+			Memo       string            `json:"memo"`
+		} `json:"value"`
+	}
+	assert.NoError(t, json.Unmarshal(exported, &file))
+	assert.Equal(t, "core/StdTx", file.Type)
+	assert.Len(t, file.Value.Msg, 1)
+	assert.Equal(t, "bank/MsgSend", file.Value.Msg[0].Type)
+	assert.Len(t, file.Value.Signatures, 1)
+	assert.Equal(t, "export", file.Value.Memo)
+
+	imported, err := ImportSignedTx(cdc, exported)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.Memo, imported.Memo)
+	assert.Equal(t, tx.Fee, imported.Fee)
+	assert.Equal(t, tx.Signatures[0].Signature, imported.Signatures[0].Signature)
+	assert.Equal(t, signMsg.Bytes(), terraauth.StdSignMsg{
+		ChainID:       signMsg.ChainID,
+		AccountNumber: signMsg.AccountNumber,
+		Sequence:      signMsg.Sequence,
+		Fee:           imported.Fee,
+		Msgs:          imported.Msgs,
+		Memo:          imported.Memo,
+	}.Bytes())
+}
@@ -0,0 +1,58 @@
+package terra
+
+import (
+	"context"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// PrepareForExternalSigning builds the sign doc for from without a local key.
+// signBytes are the exact bytes the external signer has to sign.
+func PrepareForExternalSigning(
+	ctx context.Context,
+	client Client,
+	from cosmostypes.AccAddress,
+	opts CreateTxOptions,
+) ([]byte, terraauth.StdSignMsg, error) {
+	nodeInfo, err := client.Tendermint().GetNodeInfo(ctx)
+	if err != nil {
+		return nil, terraauth.StdSignMsg{}, errors.Wrap(err, "fetch node info")
+	}
+
+	accInfo, err := client.Auth().GetAccountInfo(ctx, from)
+	if err != nil {
+		return nil, terraauth.StdSignMsg{}, errors.Wrap(err, "fetch account info")
+	}
+
+	sequence := accInfo.GetSequence()
+	if opts.Sequence != nil && sequence < *opts.Sequence {
+		sequence = *opts.Sequence
+	}
+
+	signMsg, err := createSignMsg(ctx, client, nodeInfo.Network, accInfo.GetAccountNumber(), sequence, from, opts)
+	if err != nil {
+		return nil, terraauth.StdSignMsg{}, errors.Wrap(err, "create sign msg")
+	}
+	return signMsg.Bytes(), signMsg, nil
+}
+
+// AttachExternalSignature assembles the signed tx after checking signature against the sign doc.
+func AttachExternalSignature(
+	signMsg terraauth.StdSignMsg,
+	pubKey crypto.PubKey,
+	signature []byte,
+) (terraauth.StdTx, error) {
+	if !pubKey.VerifyBytes(signMsg.Bytes(), signature) {
+		return terraauth.StdTx{}, ErrInvalidSignature
+	}
+
+	return terraauth.NewStdTx(
+		signMsg.Msgs,
+		signMsg.Fee,
+		[]terraauth.StdSignature{{PubKey: pubKey, Signature: signature}},
+		signMsg.Memo,
+	), nil
+}
@@ -0,0 +1,126 @@
+package terra
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosauthrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
+	cosmosauth "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestExternalSigning(t *testing.T) {
+	externalKey := secp256k1.GenPrivKey()
+	from := cosmostypes.AccAddress(externalKey.PubKey().Address())
+
+	signMsg := terraauth.StdSignMsg{
+		ChainID:       "bombay-12",
+		AccountNumber: 10,
+		Sequence:      3,
+		Fee: terraauth.StdFee{
+			Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 30000)),
+			Gas:    200000,
+		},
+		Msgs: []cosmostypes.Msg{terrabank.MsgSend{
+			FromAddress: from,
+			ToAddress:   from,
+			Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+		}},
+	}
+
+	signature, err := externalKey.Sign(signMsg.Bytes())
+	assert.NoError(t, err)
+
+	tx, err := AttachExternalSignature(signMsg, externalKey.PubKey(), signature)
+	assert.NoError(t, err)
+	assert.Len(t, tx.Signatures, 1)
+	assert.True(t, tx.Signatures[0].PubKey.VerifyBytes(signMsg.Bytes(), tx.Signatures[0].Signature))
+	assert.NoError(t, tx.ValidateBasic())
+
+	_, err = AttachExternalSignature(signMsg, secp256k1.GenPrivKey().PubKey(), signature)
+	assert.Equal(t, ErrInvalidSignature, err)
+}
+
+func TestExternalSigningEndToEnd(t *testing.T) {
+	ctx := context.Background()
+	cdc := MakeCodec()
+	externalKey := secp256k1.GenPrivKey()
+	from := cosmostypes.AccAddress(externalKey.PubKey().Address())
+
+	var broadcast terraauth.StdTx
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/node_info":
+			_, _ = w.Write([]byte(`{"node_info":{"network":"bombay-12"}}`))
+		case "/auth/accounts/" + from.String():
+			bz, err := cdc.MarshalJSON(struct {
+				Height string             `json:"height"`
+				Result cosmosauth.Account `json:"result"`
+			}{Height: "100", Result: authtypes.NewBaseAccount(from, nil, nil, 10, 3)})
+			assert.NoError(t, err)
+			_, _ = w.Write(bz)
+		case "/txs":
+			bz, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var req cosmosauthrest.BroadcastReq
+			assert.NoError(t, cdc.UnmarshalJSON(bz, &req))
+			broadcast = req.Tx
+			_, _ = w.Write([]byte(`{"height":"0","txhash":"ABCD","code":0}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(httpclient.New(cdc, server.URL))
+
+	fee := terraauth.StdFee{Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 30000)), Gas: 200000}
+	signBytes, signMsg, err := PrepareForExternalSigning(ctx, client, from, CreateTxOptions{
+		Msgs: []cosmostypes.Msg{terrabank.NewMsgSend(
+			from,
+			cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+			cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+		)},
+		Fee:  &fee,
+		Memo: "signed offline",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "bombay-12", signMsg.ChainID)
+	assert.Equal(t, uint64(10), signMsg.AccountNumber)
+	assert.Equal(t, uint64(3), signMsg.Sequence)
+
+	// the signer only ever sees signBytes
+	signature, err := externalKey.Sign(signBytes)
+	assert.NoError(t, err)
+	tx, err := AttachExternalSignature(signMsg, externalKey.PubKey(), signature)
+	assert.NoError(t, err)
+
+	resp, err := client.Transaction().BroadcastTx(ctx, tx, types.ModeSync)
+	assert.NoError(t, err)
+	assert.Equal(t, "ABCD", resp.TxHash)
+
+	// what the node received verifies against the sign doc it rebuilds from chain state
+	assert.NoError(t, broadcast.ValidateBasic())
+	rebuilt := terraauth.StdSignMsg{
+		ChainID:       "bombay-12",
+		AccountNumber: 10,
+		Sequence:      3,
+		Fee:           broadcast.Fee,
+		Msgs:          broadcast.Msgs,
+		Memo:          broadcast.Memo,
+	}
+	assert.Len(t, broadcast.Signatures, 1)
+	assert.True(t, externalKey.PubKey().VerifyBytes(rebuilt.Bytes(), broadcast.Signatures[0].Signature))
+	assert.Equal(t, "signed offline", broadcast.Memo)
+}
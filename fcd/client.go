@@ -0,0 +1,138 @@
+package fcd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// Public FCD endpoints run by Terraform Labs.
+const (
+	MainnetURL = "https://fcd.terra.dev"
+	TestnetURL = "https://bombay-fcd.terra.dev"
+)
+
+// Tier is a priority level FCD recommends a gas price for.
+type Tier string
+
+const (
+	TierLow     Tier = "low"
+	TierAverage Tier = "average"
+	TierHigh    Tier = "high"
+)
+
+// GasPriceTiers are the prices of a denom by tier. A denom FCD gives a single price for has it at every tier.
+type GasPriceTiers map[Tier]cosmostypes.Dec
+
+// Lowest returns the lowest price of the tiers, zero if there are none.
+func (t GasPriceTiers) Lowest() cosmostypes.Dec {
+	var lowest cosmostypes.Dec
+	for _, price := range t {
+		if lowest.IsNil() || price.LT(lowest) {
+			lowest = price
+		}
+	}
+	if lowest.IsNil() {
+		return cosmostypes.ZeroDec()
+	}
+	return lowest
+}
+
+//go:generate mockgen -destination ../../../test/mocks/terra/fcd/client.go . Client
+type Client interface {
+	GetGasPrices(ctx context.Context) (map[string]cosmostypes.Dec, error)
+	GetGasPricesAt(ctx context.Context, tier Tier) (map[string]cosmostypes.Dec, error)
+	GetGasPriceTiers(ctx context.Context) (map[string]GasPriceTiers, error)
+}
+
+type fcdClient struct {
+	client httpclient.Client
+}
+
+// New wraps a client pointed at an FCD, e.g. MainnetURL, rather than the LCD.
+func New(client httpclient.Client) Client {
+	return fcdClient{client: client}
+}
+
+// GetGasPrices returns the lowest tier of the prices FCD recommends per denom, the ones
+// service.WithGasPriceCheck should hold a tx to.
+func (c fcdClient) GetGasPrices(ctx context.Context) (map[string]cosmostypes.Dec, error) {
+	tiers, err := c.GetGasPriceTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]cosmostypes.Dec, len(tiers))
+	for denom, t := range tiers {
+		prices[denom] = t.Lowest()
+	}
+	return prices, nil
+}
+
+// GetGasPricesAt returns the prices FCD recommends at tier. Denoms without a price at tier are left out.
+func (c fcdClient) GetGasPricesAt(ctx context.Context, tier Tier) (map[string]cosmostypes.Dec, error) {
+	tiers, err := c.GetGasPriceTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]cosmostypes.Dec, len(tiers))
+	for denom, t := range tiers {
+		if price, ok := t[tier]; ok {
+			prices[denom] = price
+		}
+	}
+	return prices, nil
+}
+
+// GetGasPriceTiers returns the prices FCD recommends per denom and tier. FCD answers either a price
+// per denom or an object of prices by tier per denom.
+func (c fcdClient) GetGasPriceTiers(ctx context.Context) (map[string]GasPriceTiers, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/v1/txs/gas_prices",
+	}
+
+	var body map[string]json.RawMessage
+	if err := c.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+
+	tiers := make(map[string]GasPriceTiers, len(body))
+	for denom, raw := range body {
+		var byTier map[Tier]string
+		var flat string
+		if err := json.Unmarshal(raw, &flat); err == nil {
+			byTier = map[Tier]string{TierLow: flat, TierAverage: flat, TierHigh: flat}
+		} else if err := json.Unmarshal(raw, &byTier); err != nil {
+			return nil, errors.Wrapf(err, "decode gas prices of %s", denom)
+		}
+
+		tiers[denom] = make(GasPriceTiers, len(byTier))
+		for tier, price := range byTier {
+			dec, err := cosmostypes.NewDecFromStr(price)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse %s gas price of %s", tier, denom)
+			}
+			tiers[denom][tier] = dec
+		}
+	}
+	return tiers, nil
+}
+
+// DecCoins turns prices into the gas prices EstimateFee and CreateTxOptions take.
+func DecCoins(prices map[string]cosmostypes.Dec) cosmostypes.DecCoins {
+	coins := make(cosmostypes.DecCoins, 0, len(prices))
+	for denom, price := range prices {
+		coins = append(coins, cosmostypes.NewDecCoinFromDec(denom, price))
+	}
+	sort.Sort(coins)
+	return coins
+}
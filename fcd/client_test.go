@@ -0,0 +1,68 @@
+package fcd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/tj/assert"
+)
+
+func TestGetGasPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/txs/gas_prices", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uluna":"0.01133","uusd":"0.15","ukrw":"178.05"}`))
+	}))
+	defer server.Close()
+
+	prices, err := New(httpclient.New(nil, server.URL)).GetGasPrices(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, prices, 3)
+	assert.Equal(t, "0.011330000000000000", prices["uluna"].String())
+	assert.Equal(t, "0.150000000000000000", prices["uusd"].String())
+	assert.Equal(t, "178.050000000000000000", prices["ukrw"].String())
+
+	assert.Equal(t, "178.050000000000000000ukrw,0.011330000000000000uluna,0.150000000000000000uusd", DecCoins(prices).String())
+}
+
+func TestGetGasPriceTiers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"uluna":{"low":"0.01133","average":"0.015","high":"0.02"},
+			"uusd":{"average":"0.15","high":"0.2"},
+			"ukrw":"178.05"
+		}`))
+	}))
+	defer server.Close()
+	client := New(httpclient.New(nil, server.URL))
+	ctx := context.Background()
+
+	tiers, err := client.GetGasPriceTiers(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tiers, 3)
+	assert.Equal(t, "0.015000000000000000", tiers["uluna"][TierAverage].String())
+	assert.Equal(t, "178.050000000000000000", tiers["ukrw"][TierHigh].String())
+
+	// the minimum tier of each denom, for service.WithGasPriceCheck
+	prices, err := client.GetGasPrices(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.011330000000000000", prices["uluna"].String())
+	assert.Equal(t, "0.150000000000000000", prices["uusd"].String())
+	assert.Equal(t, "178.050000000000000000", prices["ukrw"].String())
+
+	prices, err = client.GetGasPricesAt(ctx, TierLow)
+	assert.NoError(t, err)
+	assert.Len(t, prices, 2)
+	assert.Equal(t, "0.011330000000000000", prices["uluna"].String())
+	assert.Equal(t, "178.050000000000000000", prices["ukrw"].String())
+
+	prices, err = client.GetGasPricesAt(ctx, TierHigh)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.200000000000000000", prices["uusd"].String())
+}
@@ -12,7 +12,9 @@ go 1.15
 require (
 	github.com/airbloc/logger v1.4.5
 	github.com/aws/aws-sdk-go v1.37.25
+	github.com/btcsuite/btcd v0.20.1-beta
 	github.com/cosmos/cosmos-sdk v0.39.2
+	github.com/cosmos/go-bip39 v0.0.0-20180819234021-555e2067c45d
 	github.com/ethereum/go-ethereum v1.10.1
 	github.com/pkg/errors v0.9.1
 	github.com/smartystreets/goconvey v1.6.4
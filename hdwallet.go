@@ -0,0 +1,146 @@
+package terra
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	bip39 "github.com/cosmos/go-bip39"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terratypes "github.com/terra-project/core/types"
+)
+
+const (
+	// DefaultHDGapLimit is the BIP44 address gap limit.
+	DefaultHDGapLimit        = 20
+	DefaultHDScanConcurrency = 5
+)
+
+// HDWallet derives keys from a BIP39 mnemonic along m/44'/330'/account'/0/index.
+type HDWallet struct {
+	master    [32]byte
+	chainCode [32]byte
+}
+
+func NewHDWallet(mnemonic, bip39Passphrase string) (HDWallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
+	if err != nil {
+		return HDWallet{}, errors.Wrap(err, "mnemonic to seed")
+	}
+	master, chainCode := hd.ComputeMastersFromSeed(seed)
+	return HDWallet{master: master, chainCode: chainCode}, nil
+}
+
+func (w HDWallet) Derive(account, index uint32) (Key, error) {
+	path := hd.NewFundraiserParams(account, terratypes.CoinType, index)
+	derived, err := hd.DerivePrivateKeyForPath(w.master, w.chainCode, path.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "derive %s", path.String())
+	}
+	return rawKey{privKey: secp256k1.PrivKeySecp256k1(derived)}, nil
+}
+
+type HDScanOptions struct {
+	Account uint32
+	// From is the first address index to scan. To, if not zero, is the index to stop before.
+	From, To uint32
+	// GapLimit stops the scan after that many consecutive addresses without balance.
+	// Defaults to DefaultHDGapLimit.
+	GapLimit int
+	// Concurrency bounds the balance queries in flight. Defaults to DefaultHDScanConcurrency.
+	Concurrency int
+}
+
+type HDAddressBalance struct {
+	Index   uint32
+	Address cosmostypes.AccAddress
+	Balance cosmostypes.Coins
+}
+
+type HDScanResult struct {
+	Total cosmostypes.Coins
+	// Addresses are every scanned address in index order, including the trailing empty ones.
+	Addresses []HDAddressBalance
+}
+
+// ScanHDBalances sums the balances of the addresses of wallet, scanning indices in order
+// until the gap limit or the end of the range is reached.
+func ScanHDBalances(ctx context.Context, client Client, wallet HDWallet, opts HDScanOptions) (HDScanResult, error) {
+	if opts.GapLimit <= 0 {
+		opts.GapLimit = DefaultHDGapLimit
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultHDScanConcurrency
+	}
+
+	var (
+		result HDScanResult
+		gap    int
+	)
+	for next := opts.From; opts.To == 0 || next < opts.To; {
+		size := uint32(opts.Concurrency)
+		if opts.To != 0 && opts.To-next < size {
+			size = opts.To - next
+		}
+
+		batch, err := fetchHDBalances(ctx, client, wallet, opts.Account, next, size)
+		if err != nil {
+			return HDScanResult{}, err
+		}
+		for _, balance := range batch {
+			result.Addresses = append(result.Addresses, balance)
+			result.Total = result.Total.Add(balance.Balance...)
+
+			if balance.Balance.Empty() {
+				gap++
+			} else {
+				gap = 0
+			}
+			if gap >= opts.GapLimit {
+				return result, nil
+			}
+		}
+		next += size
+	}
+	return result, nil
+}
+
+func fetchHDBalances(
+	ctx context.Context,
+	client Client,
+	wallet HDWallet,
+	account, from, size uint32,
+) ([]HDAddressBalance, error) {
+	balances := make([]HDAddressBalance, size)
+	errs := make([]error, size)
+
+	var wg sync.WaitGroup
+	for i := range balances {
+		key, err := wallet.Derive(account, from+uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		balances[i] = HDAddressBalance{Index: from + uint32(i), Address: key.AccAddress()}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.Bank().GetBalance(ctx, balances[i].Address)
+			if err != nil {
+				errs[i] = errors.Wrapf(err, "fetch balance of index %d", balances[i].Index)
+				return
+			}
+			balances[i].Balance = resp.Balance
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return balances, nil
+}
@@ -0,0 +1,61 @@
+package terra
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/tj/assert"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestScanHDBalances(t *testing.T) {
+	wallet, err := NewHDWallet(testMnemonic, "")
+	assert.NoError(t, err)
+
+	funded := map[string]string{}
+	for i, amount := range []string{"1000", "0", "500"} {
+		key, err := wallet.Derive(0, uint32(i))
+		assert.NoError(t, err)
+		funded[key.AccAddress().String()] = amount
+	}
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		addr := strings.TrimPrefix(r.URL.Path, "/bank/balances/")
+
+		result := `[]`
+		if amount, ok := funded[addr]; ok && amount != "0" {
+			result = `[{"denom":"uluna","amount":"` + amount + `"}]`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"100","result":` + result + `}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(httpclient.New(MakeCodec(), server.URL))
+	result, err := ScanHDBalances(context.Background(), client, wallet, HDScanOptions{
+		GapLimit:    4,
+		Concurrency: 2,
+	})
+	assert.NoError(t, err)
+
+	// index 2 is the last funded one, so 3 to 6 make the gap
+	assert.Len(t, result.Addresses, 7)
+	assert.Equal(t, "1500uluna", result.Total.String())
+	assert.Equal(t, "1000uluna", result.Addresses[0].Balance.String())
+	assert.True(t, result.Addresses[1].Balance.Empty())
+	assert.Equal(t, "500uluna", result.Addresses[2].Balance.String())
+	for i, balance := range result.Addresses {
+		assert.Equal(t, uint32(i), balance.Index)
+	}
+	// the last batch of 2 covers indices 6 and 7
+	assert.Equal(t, int32(8), atomic.LoadInt32(&requests))
+}
@@ -0,0 +1,49 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// renameFields renames object keys at any depth of rawBody from an alias to the name it stands for.
+// A key already present under its standard name is left as is.
+func renameFields(rawBody []byte, aliases map[string]string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawBody))
+	decoder.UseNumber() // keep big ints and decs as they are
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, errors.Wrap(err, "parse response body for field aliases")
+	}
+	return json.Marshal(renameValue(v, aliases))
+}
+
+func renameValue(v interface{}, aliases map[string]string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			renamed[key] = renameValue(value, aliases)
+		}
+		for alias, name := range aliases {
+			value, ok := renamed[alias]
+			if !ok {
+				continue
+			}
+			delete(renamed, alias)
+			if _, exists := v[name]; !exists {
+				renamed[name] = value
+			}
+		}
+		return renamed
+	case []interface{}:
+		for i, value := range v {
+			v[i] = renameValue(value, aliases)
+		}
+		return v
+	default:
+		return v
+	}
+}
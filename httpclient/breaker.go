@@ -0,0 +1,141 @@
+package httpclient
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a single probe request is let through.
+	OpenTimeout time.Duration
+	// SuccessThreshold is the number of consecutive successes after a recovery
+	// before the endpoint is reported healthy again.
+	SuccessThreshold int
+}
+
+// circuitBreaker fails requests fast while an endpoint is down. A nil breaker lets everything through.
+type circuitBreaker struct {
+	config BreakerConfig
+	now    func() time.Time
+
+	mutex      sync.Mutex
+	state      BreakerState
+	failures   int
+	successes  int
+	recovering bool
+	openedAt   time.Time
+}
+
+func newCircuitBreaker(config BreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 1
+	}
+	return &circuitBreaker{config: config, now: time.Now}
+}
+
+// allow reports whether a request may go out, moving an expired open circuit to half-open.
+// Only one probe is let through while half-open.
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.config.OpenTimeout {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		return true
+	case BreakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !isEndpointFailure(err) {
+		// a successful probe closes the circuit and forgets earlier failures
+		if b.state == BreakerHalfOpen {
+			b.state = BreakerClosed
+			b.recovering = true
+			b.successes = 0
+		}
+		b.failures = 0
+		b.successes++
+		if b.recovering && b.successes >= b.config.SuccessThreshold {
+			b.recovering = false
+		}
+		return
+	}
+
+	b.successes = 0
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// release gives back the probe of a half-open circuit without an outcome, e.g. when its caller gave
+// up, so the next request probes again instead of the circuit staying half-open for good.
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		// openedAt is kept, so the open timeout has already passed
+		b.state = BreakerOpen
+	}
+}
+
+// healthy is false while the circuit isn't closed or is still recovering.
+func (b *circuitBreaker) healthy() bool {
+	if b == nil {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state == BreakerClosed && !b.recovering
+}
+
+func (b *circuitBreaker) snapshot() (BreakerState, int) {
+	if b == nil {
+		return BreakerClosed, 0
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state, b.failures
+}
+
+// isEndpointFailure tells a down endpoint apart from errors any endpoint would answer with, e.g. a 404.
+func isEndpointFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	code := StatusCode(err)
+	return code == 0 || code >= http.StatusInternalServerError
+}
@@ -0,0 +1,175 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tj/assert"
+)
+
+func TestCircuitBreakerRecovery(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(BreakerConfig{
+		FailureThreshold: 3,
+		OpenTimeout:      time.Minute,
+		SuccessThreshold: 2,
+	})
+	breaker.now = func() time.Time { return now }
+
+	down := &StatusError{StatusCode: http.StatusBadGateway}
+	for i := 0; i < 3; i++ {
+		assert.True(t, breaker.allow())
+		breaker.record(down)
+	}
+	state, failures := breaker.snapshot()
+	assert.Equal(t, BreakerOpen, state)
+	assert.Equal(t, 3, failures)
+	assert.False(t, breaker.allow())
+
+	// a single probe once the open timeout passes
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow())
+	assert.False(t, breaker.allow())
+	state, _ = breaker.snapshot()
+	assert.Equal(t, BreakerHalfOpen, state)
+
+	breaker.record(nil)
+	state, failures = breaker.snapshot()
+	assert.Equal(t, BreakerClosed, state)
+	assert.Equal(t, 0, failures)
+	assert.False(t, breaker.healthy())
+
+	// a transient failure while recovering starts counting from zero instead of re-opening
+	breaker.record(down)
+	state, failures = breaker.snapshot()
+	assert.Equal(t, BreakerClosed, state)
+	assert.Equal(t, 1, failures)
+
+	breaker.record(nil)
+	assert.False(t, breaker.healthy())
+	breaker.record(nil)
+	assert.True(t, breaker.healthy())
+	_, failures = breaker.snapshot()
+	assert.Equal(t, 0, failures)
+
+	// answers like 404 mean the endpoint is up
+	breaker.record(&StatusError{StatusCode: http.StatusNotFound})
+	assert.True(t, breaker.healthy())
+}
+
+func TestCircuitBreakerFailedProbe(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute, SuccessThreshold: 1})
+	breaker.now = func() time.Time { return now }
+
+	refused := errors.New("connection refused")
+	breaker.record(refused)
+	assert.False(t, breaker.allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.allow())
+	breaker.record(refused)
+	state, _ := breaker.snapshot()
+	assert.Equal(t, BreakerOpen, state)
+	assert.False(t, breaker.allow())
+}
+
+func TestCircuitBreakerCanceledProbe(t *testing.T) {
+	var (
+		mode    int32 // 0 down, 1 hangs, 2 up
+		handled = make(chan struct{}, 1)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.LoadInt32(&mode) {
+		case 0:
+			w.WriteHeader(http.StatusBadGateway)
+		case 1:
+			handled <- struct{}{}
+			<-r.Context().Done()
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c := New(nil, server.URL, WithRetry(0, 0), WithCircuitBreaker(BreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+		SuccessThreshold: 1,
+	})).(client)
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.breaker.now = func() time.Time { return now }
+
+	request := func(ctx context.Context) error {
+		resp, err := c.Request(RequestPayload{Context: ctx, Method: http.MethodGet, Path: "/node_info"})
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	assert.Error(t, request(context.Background()))
+	state, _ := c.breaker.snapshot()
+	assert.Equal(t, BreakerOpen, state)
+
+	// the probe's caller gives up, which leaves the circuit open rather than half-open
+	now = now.Add(time.Minute)
+	atomic.StoreInt32(&mode, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-handled
+		cancel()
+	}()
+	assert.Error(t, request(ctx))
+	state, failures := c.breaker.snapshot()
+	assert.Equal(t, BreakerOpen, state)
+	assert.Equal(t, 1, failures)
+
+	// so the next request probes again and closes it
+	atomic.StoreInt32(&mode, 2)
+	assert.NoError(t, request(nil))
+	state, failures = c.breaker.snapshot()
+	assert.Equal(t, BreakerClosed, state)
+	assert.Equal(t, 0, failures)
+}
+
+func TestCircuitBreakerIgnoresSlotWait(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := New(nil, server.URL, WithRetry(0, 0), WithMaxConcurrency(1), WithCircuitBreaker(BreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+	})).(client)
+
+	go func() {
+		resp, err := c.Request(RequestPayload{Context: context.Background(), Method: http.MethodGet, Path: "/node_info"})
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	// wait for the first request to take the only slot
+	for len(c.sem) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.Request(RequestPayload{Context: ctx, Method: http.MethodGet, Path: "/node_info"})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	state, failures := c.breaker.snapshot()
+	assert.Equal(t, BreakerClosed, state)
+	assert.Equal(t, 0, failures)
+}
@@ -0,0 +1,78 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+const retryBudgetBuckets = 10
+
+type budgetBucket struct {
+	start    time.Time
+	requests int
+	retries  int
+}
+
+// retryBudget caps retries at a ratio of the requests made over a rolling window, counted
+// in buckets of a tenth of it. A nil budget allows every retry the policy asks for.
+type retryBudget struct {
+	ratio      float64
+	bucketSize time.Duration
+	now        func() time.Time
+
+	mutex   sync.Mutex
+	buckets [retryBudgetBuckets]budgetBucket
+}
+
+func newRetryBudget(ratio float64, window time.Duration) *retryBudget {
+	bucketSize := window / retryBudgetBuckets
+	if bucketSize <= 0 {
+		bucketSize = time.Millisecond
+	}
+	return &retryBudget{ratio: ratio, bucketSize: bucketSize, now: time.Now}
+}
+
+// current returns the bucket now falls in, clearing it if it last held an older interval.
+func (b *retryBudget) current() *budgetBucket {
+	start := b.now().Truncate(b.bucketSize)
+	bucket := &b.buckets[(start.UnixNano()/int64(b.bucketSize))%retryBudgetBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = budgetBucket{start: start}
+	}
+	return bucket
+}
+
+func (b *retryBudget) recordRequest() {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.current().requests++
+}
+
+// allowRetry reports whether one more retry stays within the budget, and counts it if so.
+func (b *retryBudget) allowRetry() bool {
+	if b == nil {
+		return true
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	bucket := b.current()
+	oldest := bucket.start.Add(-b.bucketSize * (retryBudgetBuckets - 1))
+
+	var requests, retries int
+	for _, bkt := range b.buckets {
+		if !bkt.start.Before(oldest) {
+			requests += bkt.requests
+			retries += bkt.retries
+		}
+	}
+	if float64(retries+1) > b.ratio*float64(requests) {
+		return false
+	}
+	bucket.retries++
+	return true
+}
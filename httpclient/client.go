@@ -2,13 +2,16 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/airbloc/logger"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -23,36 +26,69 @@ type Client interface {
 }
 
 type client struct {
-	codec    *codec.Codec
-	endpoint string
-	logger   logger.Logger
+	codec     *codec.Codec
+	endpoint  string
+	host      string
+	headers   http.Header
+	logger    logger.Logger
+	transport *http.Transport
+	inflight  *inflightGroup
+	retry     retryConfig
+	budget    *retryBudget
+	sem       semaphore
+	breaker   *circuitBreaker
+	tracer    Tracer
+	aliases   map[string]string
+	debug     io.Writer
+	redact    []string
+	validator func(path string, body []byte) error
+
+	slowThreshold time.Duration
+	slowHook      func(req RequestPayload, duration time.Duration)
+
 	*http.Client
 }
 
-func New(codec *codec.Codec, endpoint string) Client {
+func New(codec *codec.Codec, endpoint string, opts ...Option) Client {
 	if codec == nil {
 		codec = terraapp.MakeCodec()
 	}
 
-	transport := logTransport{
-		transport: http.DefaultTransport,
-		logger:    logger.New("http/transport"),
+	c := client{
+		codec:     codec,
+		endpoint:  endpoint,
+		logger:    logger.New("http/client"),
+		transport: http.DefaultTransport.(*http.Transport).Clone(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.retry.policy == nil {
+		c.retry.policy = DefaultRetryPolicy
+	} else if !c.retry.configured {
+		c.retry.maxRetries = DefaultMaxRetries
+		c.retry.backoff = DefaultRetryBackoff
 	}
 
-	return client{
-		codec:    codec,
-		endpoint: endpoint,
-		logger:   logger.New("http/client"),
-		Client:   &http.Client{Transport: transport},
+	var transport http.RoundTripper = c.transport
+	if c.debug != nil {
+		transport = newDebugTransport(transport, c.debug, c.redact)
+	}
+	c.Client = &http.Client{
+		Transport: logTransport{
+			transport: transport,
+			logger:    logger.New("http/transport"),
+		},
 	}
+	return c
 }
 
 func (c client) Codec() *codec.Codec { return c.codec }
 
-func (c client) Request(payload RequestPayload) (*http.Response, error) {
+func (c client) url(payload RequestPayload) (string, error) {
 	u, err := url.Parse(c.endpoint)
 	if err != nil {
-		return nil, errors.Wrap(err, "parse endpoint")
+		return "", errors.Wrap(err, "parse endpoint")
 	}
 	u.Path = path.Join(u.Path, payload.Path)
 
@@ -60,49 +96,216 @@ func (c client) Request(payload RequestPayload) (*http.Response, error) {
 	for k, v := range payload.Query {
 		q.Set(k, v)
 	}
+	if height := payload.height(); height > 0 {
+		// the legacy LCD reads the query param, grpc-gateway the header
+		q.Set("height", strconv.FormatInt(height, 10))
+	}
 	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
 
-	req, err := http.NewRequestWithContext(
-		payload.Context,
-		payload.Method,
-		u.String(),
-		payload.Body,
-	)
+func (c client) Request(payload RequestPayload) (resp *http.Response, err error) {
+	if payload.Context == nil {
+		payload.Context = context.Background()
+	}
+	if c.tracer != nil {
+		var span Span
+		payload, span = c.startSpan(payload)
+		defer func() { endSpan(span, resp, err) }()
+	}
+
+	if !c.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err = c.request(payload)
+	var waitErr *slotWaitError
+	if payload.Context.Err() == context.Canceled || errors.As(err, &waitErr) {
+		// callers giving up, or requests that never left the client, don't say anything about the endpoint
+		c.breaker.release()
+	} else {
+		c.breaker.record(err)
+	}
+	return resp, err
+}
+
+func (c client) request(payload RequestPayload) (*http.Response, error) {
+	u, err := c.url(payload)
 	if err != nil {
-		return nil, errors.Wrap(err, "new request with context")
+		return nil, err
+	}
+
+	// buffer the body so that it can be replayed on retries
+	var rawBody []byte
+	if payload.Body != nil {
+		if rawBody, err = ioutil.ReadAll(payload.Body); err != nil {
+			return nil, errors.Wrap(err, "read request body")
+		}
+	}
+
+	c.budget.recordRequest()
+	for attempt := 1; ; attempt++ {
+		var body io.Reader
+		if rawBody != nil {
+			body = bytes.NewReader(rawBody)
+		}
+
+		req, err := http.NewRequestWithContext(payload.Context, payload.Method, u, body)
+		if err != nil {
+			return nil, errors.Wrap(err, "new request with context")
+		}
+		if c.host != "" {
+			req.Host = c.host
+		}
+		for key, values := range c.headers {
+			req.Header[key] = append([]string(nil), values...)
+		}
+		if height := payload.height(); height > 0 {
+			req.Header.Set(HeightHeader, strconv.FormatInt(height, 10))
+		}
+		if c.tracer != nil {
+			c.tracer.Inject(payload.Context, req.Header)
+		}
+
+		if err := c.sem.acquire(payload.Context); err != nil {
+			return nil, &slotWaitError{err: err}
+		}
+
+		start := time.Now()
+		resp, err := c.Client.Do(req)
+		if err != nil {
+			c.sem.release()
+		} else if c.sem != nil {
+			resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: c.sem.release}
+		}
+		if elapsed := time.Since(start); c.slowHook != nil && elapsed > c.slowThreshold {
+			c.slowHook(payload, elapsed)
+		}
+		if err == nil {
+			return resp, nil
+		}
+		if attempt > c.retry.maxRetries || !c.retry.policy(payload, StatusCode(err), err) {
+			return nil, err
+		}
+		if !c.budget.allowRetry() {
+			c.logger.Debug("retry budget exhausted, not retrying request to {}. err={}", u, err)
+			return nil, err
+		}
+
+		c.logger.Debug("retry request to {} ({}/{}). err={}", u, attempt, c.retry.maxRetries, err)
+		select {
+		case <-payload.Context.Done():
+			return nil, payload.Context.Err()
+		case <-time.After(c.retry.backoff * time.Duration(attempt)):
+		}
 	}
-	return c.Client.Do(req)
 }
 
-func (c client) RequestJSON(payload RequestPayload, respBody interface{}) error {
+func (c client) requestRaw(payload RequestPayload) (rawResponse, error) {
 	resp, err := c.Request(payload)
 	if err != nil {
-		return errors.Wrap(err, "request")
+		return rawResponse{}, errors.Wrap(err, "request")
 	}
 	defer resp.Body.Close()
 
-	if strings.HasPrefix(payload.Path, "/wasm/contracts/") {
-		// json
-		var buf bytes.Buffer
-		resp.Body = ioutil.NopCloser(io.TeeReader(resp.Body, &buf))
+	rawBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return rawResponse{}, errors.Wrap(err, "read raw body")
+	}
+	return rawResponse{
+		contentType: resp.Header.Get("Content-Type"),
+		body:        rawBody,
+	}, nil
+}
 
-		if err := json.NewDecoder(resp.Body).Decode(respBody); err != nil {
-			if rawBody, err := ioutil.ReadAll(&buf); err == nil {
-				c.logger.Debug("failed to parse response body. rawBody={}", string(rawBody))
-			}
-			return errors.Wrap(err, "parse response body with json")
+func (c client) RequestJSON(payload RequestPayload, respBody interface{}) error {
+	var (
+		resp rawResponse
+		err  error
+	)
+	if c.inflight != nil && payload.Method == http.MethodGet && payload.Body == nil {
+		var key string
+		if key, err = c.url(payload); err != nil {
+			return err
 		}
+		resp, err = c.inflight.do(key, func() (rawResponse, error) { return c.requestRaw(payload) })
 	} else {
-		// amino
-		rawBody, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return errors.Wrap(err, "read raw body")
+		resp, err = c.requestRaw(payload)
+	}
+	if err != nil {
+		return err
+	}
+
+	rawBody := resp.body
+	if err := checkContentType(resp.contentType, rawBody); err != nil {
+		return err
+	}
+	if len(c.aliases) > 0 {
+		if rawBody, err = renameFields(rawBody, c.aliases); err != nil {
+			return err
+		}
+	}
+
+	if c.validator != nil {
+		if err := c.validator(payload.Path, rawBody); err != nil {
+			return &ValidationError{Path: payload.Path, Err: err}
 		}
+	}
+
+	if err := Decode(c.codec, payload.Path, rawBody, respBody); err != nil {
+		c.logger.Debug("failed to parse response body. rawBody={}", string(rawBody))
+		return err
+	}
+	return nil
+}
 
-		if err := c.codec.UnmarshalJSON(rawBody, respBody); err != nil {
-			c.logger.Debug("failed to parse response body. rawBody={}", string(rawBody))
-			return errors.Wrap(err, "parse response body with codec")
+// Decode parses a response body of path the way RequestJSON does. Failing that, it returns a *DecodeError.
+func Decode(codec *codec.Codec, path string, rawBody []byte, respBody interface{}) error {
+	if isJSONPath(path) {
+		// json
+		if err := json.Unmarshal(rawBody, respBody); err != nil {
+			return &DecodeError{Raw: rawBody, Err: errors.Wrap(err, "parse response body with json")}
 		}
+		return nil
+	}
+
+	// amino
+	if err := codec.UnmarshalJSON(rawBody, respBody); err != nil {
+		return &DecodeError{Raw: rawBody, Err: errors.Wrap(err, "parse response body with codec")}
 	}
 	return nil
 }
+
+// jsonPathPrefixes are served as plain json rather than amino json.
+var jsonPathPrefixes = []string{
+	"/wasm/contracts/",
+	"/cosmos/",    // grpc-gateway
+	"/ibc/",       // grpc-gateway
+	"/feemarket/", // grpc-gateway
+	"/osmosis/",   // grpc-gateway
+	"/v1/",        // fcd
+}
+
+func isJSONPath(p string) bool {
+	for _, prefix := range jsonPathPrefixes {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkContentType catches proxies answering with an HTML page (e.g. maintenance) instead of JSON.
+func checkContentType(contentType string, rawBody []byte) error {
+	trimmed := bytes.TrimSpace(rawBody)
+	looksLikeJSON := len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+	if looksLikeJSON || (strings.Contains(contentType, "json") && !bytes.HasPrefix(trimmed, []byte("<"))) {
+		return nil
+	}
+
+	snippet := trimmed
+	if len(snippet) > maxSnippetLength {
+		snippet = snippet[:maxSnippetLength]
+	}
+	return errors.Wrapf(ErrUnexpectedContentType, "content-type=%q body=%q", contentType, string(snippet))
+}
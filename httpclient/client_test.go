@@ -0,0 +1,452 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tj/assert"
+)
+
+func TestRequestJSONUnexpectedContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><body>Under maintenance</body></html>"))
+	}))
+	defer server.Close()
+
+	var body struct {
+		Height string `json:"height"`
+	}
+	err := New(nil, server.URL).RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/node_info",
+	}, &body)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrUnexpectedContentType))
+	assert.Contains(t, err.Error(), "Under maintenance")
+}
+
+func TestWithHost(t *testing.T) {
+	var receivedHost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedHost = r.Host
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"1"}`))
+	}))
+	defer server.Close()
+
+	var body struct {
+		Height string `json:"height"`
+	}
+	err := New(nil, server.URL, WithHost("lcd.terra.dev")).RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/node_info",
+	}, &body)
+	assert.NoError(t, err)
+	assert.Equal(t, "lcd.terra.dev", receivedHost)
+	assert.Equal(t, "1", body.Height)
+}
+
+func TestWithDeduplication(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"1"}`))
+	}))
+	defer server.Close()
+
+	client := New(nil, server.URL, WithDeduplication())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var body struct {
+				Height string `json:"height"`
+			}
+			err := client.RequestJSON(RequestPayload{
+				Context: context.Background(),
+				Method:  http.MethodGet,
+				Path:    "/treasury/tax_rate",
+			}, &body)
+			assert.NoError(t, err)
+			assert.Equal(t, "1", body.Height)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"temporary"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"1"}`))
+	}))
+	defer server.Close()
+
+	var consulted int32
+	client := New(
+		nil, server.URL,
+		WithRetry(3, time.Millisecond),
+		WithRetryPolicy(func(req RequestPayload, statusCode int, err error) bool {
+			atomic.AddInt32(&consulted, 1)
+			return statusCode == http.StatusInternalServerError
+		}),
+	)
+
+	var body struct {
+		Height string `json:"height"`
+	}
+	err := client.RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/node_info",
+	}, &body)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", body.Height)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&consulted))
+}
+
+func TestIdempotentRetry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"unavailable"}`))
+	}))
+	defer server.Close()
+
+	client := New(nil, server.URL, WithRetry(2, time.Millisecond))
+	post := func(idempotent bool) error {
+		var body struct{}
+		return client.RequestJSON(RequestPayload{
+			Context:    context.Background(),
+			Method:     http.MethodPost,
+			Path:       "/txs",
+			Body:       strings.NewReader(`{}`),
+			Idempotent: idempotent,
+		}, &body)
+	}
+
+	assert.Error(t, post(false))
+	assert.Equal(t, int32(1), atomic.SwapInt32(&calls, 0))
+
+	assert.Error(t, post(true))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestWithSlowRequestHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"failed"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var slow []string
+	client := New(nil, server.URL, WithSlowRequestHook(20*time.Millisecond, func(req RequestPayload, duration time.Duration) {
+		assert.True(t, duration > 20*time.Millisecond)
+		slow = append(slow, req.Path+"?fail="+req.Query["fail"])
+	}))
+	request := func(path, fail string) error {
+		var body struct{}
+		return client.RequestJSON(RequestPayload{
+			Context: context.Background(),
+			Method:  http.MethodGet,
+			Path:    path,
+			Query:   map[string]string{"fail": fail},
+		}, &body)
+	}
+
+	assert.NoError(t, request("/fast", ""))
+	assert.NoError(t, request("/slow", ""))
+	assert.Error(t, request("/slow", "1"))
+	assert.Equal(t, []string{"/slow?fail=", "/slow?fail=1"}, slow)
+}
+
+func TestWithMaxConcurrency(t *testing.T) {
+	const limit = 3
+
+	var inflight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			max := atomic.LoadInt32(&peak)
+			if current <= max || atomic.CompareAndSwapInt32(&peak, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(nil, server.URL, WithMaxConcurrency(limit))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var body struct{}
+			assert.NoError(t, client.RequestJSON(RequestPayload{
+				Context: context.Background(),
+				Method:  http.MethodGet,
+				Path:    "/node_info",
+			}, &body))
+		}()
+	}
+	wg.Wait()
+
+	assert.True(t, atomic.LoadInt32(&peak) <= limit)
+	assert.Equal(t, int32(limit), atomic.LoadInt32(&peak))
+}
+
+func TestWithMaxConcurrencyUnlimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	for _, n := range []int{0, -1} {
+		c := New(nil, server.URL, WithMaxConcurrency(n)).(client)
+		assert.Nil(t, c.sem)
+
+		// an unbuffered semaphore would block here forever
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		var body struct{}
+		assert.NoError(t, c.RequestJSON(RequestPayload{Context: ctx, Method: http.MethodGet, Path: "/node_info"}, &body))
+		cancel()
+	}
+}
+
+func TestWithDialTimeout(t *testing.T) {
+	// 10.255.255.1 is unroutable, so the dial hangs until it times out
+	client := New(nil, "http://10.255.255.1:1317", WithDialTimeout(50*time.Millisecond), WithRetry(0, 0))
+
+	start := time.Now()
+	var body struct{}
+	err := client.RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/node_info",
+	}, &body)
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestWithFieldAliases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"10","tx_hash":"ABCD","gas_used":"123","logs":[{"msg_index":0,"tx_hash":"ABCD"}]}`))
+	}))
+	defer server.Close()
+
+	payload := RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/txs/ABCD",
+	}
+	type log struct {
+		TxHash string `json:"txhash"`
+	}
+	var body struct {
+		TxHash  string `json:"txhash"`
+		GasUsed int64  `json:"gas_used"`
+		Logs    []log  `json:"logs"`
+	}
+
+	assert.NoError(t, New(nil, server.URL).RequestJSON(payload, &body))
+	assert.Empty(t, body.TxHash)
+
+	client := New(nil, server.URL, WithFieldAliases(map[string]string{"tx_hash": "txhash"}))
+	assert.NoError(t, client.RequestJSON(payload, &body))
+	assert.Equal(t, "ABCD", body.TxHash)
+	assert.Equal(t, int64(123), body.GasUsed)
+	assert.Equal(t, []log{{TxHash: "ABCD"}}, body.Logs)
+}
+
+func TestWithRetryBudget(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"down"}`))
+	}))
+	defer server.Close()
+
+	client := New(nil, server.URL, WithRetry(3, time.Millisecond), WithRetryBudget(0.1, time.Minute))
+	for i := 0; i < 20; i++ {
+		var body struct{}
+		err := client.RequestJSON(RequestPayload{
+			Context: context.Background(),
+			Method:  http.MethodGet,
+			Path:    "/node_info",
+		}, &body)
+		assert.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+	}
+
+	// 20 requests earn 2 retries, instead of 3 retries each
+	assert.Equal(t, int32(22), atomic.LoadInt32(&calls))
+}
+
+func TestWithDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+		assert.Equal(t, "token", r.Header.Get("X-Node-Token"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"height":"1"}`))
+	}))
+	defer server.Close()
+
+	var dump strings.Builder
+	client := New(
+		nil, server.URL,
+		WithHeader("X-Api-Key", "secret"),
+		WithHeader("X-Node-Token", "token"),
+		WithDebug(&dump, "X-Node-Token"),
+	)
+
+	var body struct {
+		Height string `json:"height"`
+	}
+	assert.NoError(t, client.RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodPost,
+		Path:    "/txs/estimate_fee",
+		Body:    strings.NewReader(`{"msgs":[]}`),
+	}, &body))
+	assert.Equal(t, "1", body.Height)
+
+	out := dump.String()
+	assert.Contains(t, out, "POST /txs/estimate_fee HTTP/1.1")
+	assert.Contains(t, out, `{"msgs":[]}`)
+	assert.Contains(t, out, "HTTP/1.1 202 Accepted")
+	assert.Contains(t, out, `{"height":"1"}`)
+	assert.Contains(t, out, "X-Api-Key: [REDACTED]")
+	assert.Contains(t, out, "X-Node-Token: [REDACTED]")
+	assert.NotContains(t, out, "secret")
+	assert.NotContains(t, out, "token\r\n")
+}
+
+func TestRequestJSONPartialDecode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"10","txhash":"ABCD","tx":{"type":"core/StdTx","value":{"msg":[` +
+			`{"type":"upgraded/MsgSomethingNew","value":{"sender":"terra1x46rqay4d3cssq8gxxvqz8xt6nwlz4td20k38v"}}],` +
+			`"fee":{"amount":[],"gas":"200000"},"signatures":[],"memo":""}}}`))
+	}))
+	defer server.Close()
+
+	var body cosmostypes.TxResponse
+	err := New(nil, server.URL).RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/txs/ABCD",
+	}, &body)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrPartialDecode))
+
+	raw, ok := RawBody(errors.Wrap(err, "fetch tx"))
+	assert.True(t, ok)
+	var partial struct {
+		TxHash string `json:"txhash"`
+		Tx     struct {
+			Value struct {
+				Msg []struct {
+					Type string `json:"type"`
+				} `json:"msg"`
+			} `json:"value"`
+		} `json:"tx"`
+	}
+	assert.NoError(t, json.Unmarshal(raw, &partial))
+	assert.Equal(t, "ABCD", partial.TxHash)
+	assert.Equal(t, "upgraded/MsgSomethingNew", partial.Tx.Value.Msg[0].Type)
+}
+
+func TestWithResponseValidator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/blocks/latest" {
+			_, _ = w.Write([]byte(`{"block":{"header":{"height":"10"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"height":"10","result":"0.07"}`))
+	}))
+	defer server.Close()
+
+	errMissingHeight := errors.New(`missing required field "height"`)
+	var validated []string
+	client := New(nil, server.URL, WithResponseValidator(func(path string, body []byte) error {
+		validated = append(validated, path)
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return err
+		}
+		if _, ok := fields["height"]; !ok {
+			return errMissingHeight
+		}
+		return nil
+	}))
+
+	var body struct {
+		Height string          `json:"height"`
+		Result json.RawMessage `json:"result"`
+	}
+	err := client.RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/blocks/latest",
+	}, &body)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidResponse))
+	assert.True(t, errors.Is(err, errMissingHeight))
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "/blocks/latest", validationErr.Path)
+	assert.Contains(t, err.Error(), `/blocks/latest: missing required field "height"`)
+
+	assert.NoError(t, client.RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/minting/inflation",
+	}, &body))
+	assert.Equal(t, "10", body.Height)
+	assert.Equal(t, []string{"/blocks/latest", "/minting/inflation"}, validated)
+}
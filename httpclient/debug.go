@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+// DefaultRedactedHeaders are left out of WithDebug dumps on top of the ones it's given.
+var DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key"}
+
+const redacted = "[REDACTED]"
+
+// debugTransport dumps every round trip to w, one at a time so concurrent dumps don't interleave.
+type debugTransport struct {
+	transport http.RoundTripper
+	w         io.Writer
+	redact    []string
+	mutex     *sync.Mutex
+}
+
+func newDebugTransport(transport http.RoundTripper, w io.Writer, redact []string) debugTransport {
+	return debugTransport{
+		transport: transport,
+		w:         w,
+		redact:    append(append([]string{}, DefaultRedactedHeaders...), redact...),
+		mutex:     &sync.Mutex{},
+	}
+}
+
+func (t debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqDump, err := t.dumpRequest(req)
+	t.dump("-->", reqDump, err)
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		t.dump("<--", nil, err)
+		return resp, err
+	}
+	respDump, err := httputil.DumpResponse(resp, true)
+	t.dump("<--", respDump, err)
+	return resp, nil
+}
+
+func (t debugTransport) dumpRequest(req *http.Request) ([]byte, error) {
+	dumped := req.Clone(req.Context())
+	for _, header := range t.redact {
+		if dumped.Header.Get(header) != "" {
+			dumped.Header.Set(header, redacted)
+		}
+	}
+
+	// dump a copy of the body so the one to be sent is left unread
+	withBody := req.Body == nil || req.GetBody != nil
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		dumped.Body = body
+	}
+	return httputil.DumpRequestOut(dumped, withBody)
+}
+
+func (t debugTransport) dump(prefix string, dump []byte, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if err != nil {
+		fmt.Fprintf(t.w, "%s %s\n\n", prefix, err)
+		return
+	}
+	fmt.Fprintf(t.w, "%s %s\n\n", prefix, dump)
+}
@@ -0,0 +1,46 @@
+package httpclient
+
+import "sync"
+
+type rawResponse struct {
+	contentType string
+	body        []byte
+}
+
+type inflightCall struct {
+	wg   sync.WaitGroup
+	resp rawResponse
+	err  error
+}
+
+// inflightGroup shares the result of identical concurrent requests, singleflight-style.
+type inflightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*inflightCall
+}
+
+func newInflightGroup() *inflightGroup {
+	return &inflightGroup{calls: make(map[string]*inflightCall)}
+}
+
+func (g *inflightGroup) do(key string, fn func() (rawResponse, error)) (rawResponse, error) {
+	g.mutex.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mutex.Unlock()
+		call.wg.Wait()
+		return call.resp, call.err
+	}
+	call := &inflightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mutex.Unlock()
+
+	call.resp, call.err = fn()
+	call.wg.Done()
+
+	g.mutex.Lock()
+	delete(g.calls, key)
+	g.mutex.Unlock()
+
+	return call.resp, call.err
+}
@@ -0,0 +1,90 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const maxSnippetLength = 256
+
+var (
+	ErrUnexpectedContentType = errors.New("unexpected content type")
+	ErrNoEndpoints           = errors.New("no endpoints configured")
+	ErrCircuitOpen           = errors.New("circuit breaker is open")
+	ErrPartialDecode         = errors.New("response body could not be fully decoded")
+	ErrInvalidResponse       = errors.New("response body failed validation")
+)
+
+// DecodeError is returned when a response body isn't what the client expects, most often because the node
+// knows a type the codec doesn't, e.g. a msg added in an upgrade. Raw is the body as received, so callers can
+// still pick the fields they need from it. It matches ErrPartialDecode with errors.Is.
+type DecodeError struct {
+	Raw json.RawMessage
+	Err error
+}
+
+func (e *DecodeError) Error() string        { return e.Err.Error() }
+func (e *DecodeError) Unwrap() error        { return e.Err }
+func (e *DecodeError) Is(target error) bool { return target == ErrPartialDecode }
+
+// RawBody returns the undecoded response body carried by a decode error.
+func RawBody(err error) (json.RawMessage, bool) {
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return decodeErr.Raw, true
+	}
+	return nil, false
+}
+
+// ValidationError is returned when the WithResponseValidator function rejects a response body.
+// Err is the error of the validator. It matches ErrInvalidResponse with errors.Is.
+type ValidationError struct {
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	return ErrInvalidResponse.Error() + ": " + e.Path + ": " + e.Err.Error()
+}
+func (e *ValidationError) Unwrap() error        { return e.Err }
+func (e *ValidationError) Is(target error) bool { return target == ErrInvalidResponse }
+
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string { return e.Body }
+
+// StatusCode returns the HTTP status code carried by err, or 0 if err was not caused by an error response.
+func StatusCode(err error) int {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
+func IsStatus(err error, codes ...int) bool {
+	code := StatusCode(err)
+	for _, c := range codes {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a 404, or an LCD error response stating that the entity doesn't exist.
+// The legacy LCD answers most failed queries with a 500, so the message has to be inspected as well.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode == http.StatusNotFound ||
+		strings.Contains(strings.ToLower(statusErr.Body), "not found")
+}
@@ -0,0 +1,87 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// healthWindow is how many of the latest requests the success rate of an endpoint covers.
+const healthWindow = 100
+
+// EndpointHealth is a point-in-time view of an endpoint behind a multi client.
+type EndpointHealth struct {
+	URL string
+	// State is BreakerClosed if WithCircuitBreaker isn't given.
+	State   BreakerState
+	Healthy bool
+	// SuccessRate is the share of the endpoint's latest Requests that succeeded, 1 if there were none.
+	SuccessRate float64
+	Requests    int
+	LastError   error
+	LastErrorAt time.Time
+}
+
+// HealthReporter is implemented by the clients NewMultiClient returns.
+type HealthReporter interface {
+	HealthSnapshot() []EndpointHealth
+}
+
+type endpointStats struct {
+	mutex       sync.Mutex
+	outcomes    [healthWindow]bool
+	count       int
+	next        int
+	lastErr     error
+	lastErrorAt time.Time
+}
+
+func (s *endpointStats) record(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	failed := isEndpointFailure(err)
+	s.outcomes[s.next] = !failed
+	s.next = (s.next + 1) % healthWindow
+	if s.count < healthWindow {
+		s.count++
+	}
+	if failed {
+		s.lastErr = err
+		s.lastErrorAt = time.Now()
+	}
+}
+
+func (s *endpointStats) snapshot() (successRate float64, requests int, lastErr error, lastErrorAt time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.count == 0 {
+		return 1, 0, s.lastErr, s.lastErrorAt
+	}
+	var successes int
+	for i := 0; i < s.count; i++ {
+		if s.outcomes[i] {
+			successes++
+		}
+	}
+	return float64(successes) / float64(s.count), s.count, s.lastErr, s.lastErrorAt
+}
+
+// HealthSnapshot returns the health of every endpoint, in the order they are tried.
+func (c multiClient) HealthSnapshot() []EndpointHealth {
+	snapshot := make([]EndpointHealth, len(c.endpoints))
+	for i, endpoint := range c.endpoints {
+		state, _ := endpoint.breaker.snapshot()
+		successRate, requests, lastErr, lastErrorAt := endpoint.stats.snapshot()
+		snapshot[i] = EndpointHealth{
+			URL:         endpoint.url,
+			State:       state,
+			Healthy:     endpoint.breaker.healthy(),
+			SuccessRate: successRate,
+			Requests:    requests,
+			LastError:   lastErr,
+			LastErrorAt: lastErrorAt,
+		}
+	}
+	return snapshot
+}
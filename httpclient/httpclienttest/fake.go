@@ -0,0 +1,157 @@
+package httpclienttest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/pkg/errors"
+	terraapp "github.com/terra-project/core/app"
+)
+
+// Call is a request received by FakeClient. Body holds the request body, read up front.
+type Call struct {
+	Payload httpclient.RequestPayload
+	Body    []byte
+}
+
+type stub struct {
+	status int
+	body   []byte
+	err    error
+}
+
+// FakeClient is an in-memory httpclient.Client serving canned responses per method and path.
+// Unstubbed requests fail with a 404 StatusError, like an LCD would.
+type FakeClient struct {
+	codec *codec.Codec
+
+	mutex sync.Mutex
+	stubs map[string]stub
+	calls []Call
+}
+
+var _ httpclient.Client = (*FakeClient)(nil)
+
+func NewFakeClient(codec *codec.Codec) *FakeClient {
+	if codec == nil {
+		codec = terraapp.MakeCodec()
+	}
+	return &FakeClient{codec: codec, stubs: make(map[string]stub)}
+}
+
+func key(method, path string) string { return method + " " + path }
+
+// Stub answers method + path with body and a 200.
+func (c *FakeClient) Stub(method, path, body string) {
+	c.StubStatus(method, path, http.StatusOK, body)
+}
+
+// StubStatus answers method + path with body and status. Statuses >= 400 surface as a StatusError.
+func (c *FakeClient) StubStatus(method, path string, status int, body string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stubs[key(method, path)] = stub{status: status, body: []byte(body)}
+}
+
+// StubError fails method + path with err, e.g. to simulate connection errors.
+func (c *FakeClient) StubError(method, path string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stubs[key(method, path)] = stub{err: err}
+}
+
+func (c *FakeClient) Codec() *codec.Codec { return c.codec }
+
+func (c *FakeClient) Request(payload httpclient.RequestPayload) (*http.Response, error) {
+	s, err := c.serve(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: s.status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(s.body)),
+	}, nil
+}
+
+func (c *FakeClient) RequestJSON(payload httpclient.RequestPayload, respBody interface{}) error {
+	s, err := c.serve(payload)
+	if err != nil {
+		return err
+	}
+	return httpclient.Decode(c.codec, payload.Path, s.body, respBody)
+}
+
+func (c *FakeClient) serve(payload httpclient.RequestPayload) (stub, error) {
+	var body []byte
+	if payload.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(payload.Body); err != nil {
+			return stub{}, errors.Wrap(err, "read request body")
+		}
+		payload.Body = bytes.NewReader(body)
+	}
+
+	c.mutex.Lock()
+	c.calls = append(c.calls, Call{Payload: payload, Body: body})
+	s, ok := c.stubs[key(payload.Method, payload.Path)]
+	c.mutex.Unlock()
+
+	switch {
+	case !ok:
+		return stub{}, &httpclient.StatusError{
+			StatusCode: http.StatusNotFound,
+			Body:       fmt.Sprintf("no stub for %s", key(payload.Method, payload.Path)),
+		}
+	case s.err != nil:
+		return stub{}, s.err
+	case s.status >= 400:
+		return stub{}, &httpclient.StatusError{StatusCode: s.status, Body: string(s.body)}
+	default:
+		return s, nil
+	}
+}
+
+// Calls returns every request received so far, in order.
+func (c *FakeClient) Calls() []Call {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// CallsTo returns the requests received for method + path.
+func (c *FakeClient) CallsTo(method, path string) []Call {
+	var calls []Call
+	for _, call := range c.Calls() {
+		if call.Payload.Method == method && call.Payload.Path == path {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// AssertCalled fails t unless method + path was requested, and returns the last such request.
+func (c *FakeClient) AssertCalled(t testing.TB, method, path string) Call {
+	t.Helper()
+	calls := c.CallsTo(method, path)
+	if len(calls) == 0 {
+		t.Errorf("expected a call to %s", key(method, path))
+		return Call{}
+	}
+	return calls[len(calls)-1]
+}
+
+// AssertNotCalled fails t if method + path was requested.
+func (c *FakeClient) AssertNotCalled(t testing.TB, method, path string) {
+	t.Helper()
+	if calls := c.CallsTo(method, path); len(calls) > 0 {
+		t.Errorf("expected no call to %s, got %d", key(method, path), len(calls))
+	}
+}
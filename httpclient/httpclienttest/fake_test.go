@@ -0,0 +1,69 @@
+package httpclienttest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/service"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	terraauth "github.com/terra-project/core/x/auth"
+	"github.com/tj/assert"
+)
+
+func TestFakeClientStub(t *testing.T) {
+	fake := NewFakeClient(nil)
+	fake.Stub(http.MethodGet, "/treasury/tax_rate", `{"height":"100","result":"0.005000000000000000"}`)
+
+	resp, err := service.NewTreasuryService(fake).GetTaxRate(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), resp.Height)
+	assert.Equal(t, "0.005000000000000000", resp.TaxRate.String())
+
+	fake.AssertCalled(t, http.MethodGet, "/treasury/tax_rate")
+	fake.AssertNotCalled(t, http.MethodGet, "/treasury/tax_cap/uusd")
+}
+
+func TestFakeClientPayload(t *testing.T) {
+	fake := NewFakeClient(nil)
+	fake.Stub(http.MethodPost, "/txs/estimate_fee", `{"height":"100","result":{"fee":{"amount":[{"denom":"uluna","amount":"3000"}],"gas":"20000"}}}`)
+
+	gasPrices := cosmostypes.DecCoins{{Denom: "uluna", Amount: cosmostypes.NewDecWithPrec(15, 2)}}
+	_, err := service.NewTransactionService(fake).EstimateFee(
+		context.Background(),
+		"terra12avq876h9mn3wehchcezaafd4kdyjzer4u79kc",
+		terraauth.StdSignMsg{ChainID: "bombay-12", Memo: "hello"},
+		"1.4",
+		gasPrices,
+	)
+	assert.NoError(t, err)
+
+	call := fake.AssertCalled(t, http.MethodPost, "/txs/estimate_fee")
+	var sent struct {
+		BaseReq struct {
+			From          string `json:"from"`
+			Memo          string `json:"memo"`
+			ChainID       string `json:"chain_id"`
+			GasAdjustment string `json:"gas_adjustment"`
+		} `json:"base_req"`
+	}
+	assert.NoError(t, json.Unmarshal(call.Body, &sent))
+	assert.Equal(t, "terra12avq876h9mn3wehchcezaafd4kdyjzer4u79kc", sent.BaseReq.From)
+	assert.Equal(t, "hello", sent.BaseReq.Memo)
+	assert.Equal(t, "bombay-12", sent.BaseReq.ChainID)
+	assert.Equal(t, "1.4", sent.BaseReq.GasAdjustment)
+}
+
+func TestFakeClientNotFound(t *testing.T) {
+	fake := NewFakeClient(nil)
+	fake.StubStatus(http.MethodGet, "/txs/ABCD", http.StatusInternalServerError, `{"error":"internal"}`)
+
+	_, err := service.NewTransactionService(fake).GetTxByHash(context.Background(), "ABCD")
+	assert.Equal(t, http.StatusInternalServerError, httpclient.StatusCode(err))
+
+	_, err = service.NewTransactionService(fake).GetTxByHash(context.Background(), "EFGH")
+	assert.True(t, httpclient.IsNotFound(err))
+}
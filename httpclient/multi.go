@@ -0,0 +1,150 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/airbloc/logger"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/pkg/errors"
+	terraapp "github.com/terra-project/core/app"
+)
+
+// Endpoint is a single LCD behind a multi client.
+// Timeout bounds each request to it; zero leaves it to the caller's context.
+type Endpoint struct {
+	URL     string
+	Timeout time.Duration
+}
+
+type endpointClient struct {
+	Client
+	url     string
+	timeout time.Duration
+	breaker *circuitBreaker
+	stats   *endpointStats
+}
+
+type multiClient struct {
+	codec     *codec.Codec
+	logger    logger.Logger
+	endpoints []endpointClient
+}
+
+// NewMultiClient tries endpoints in order, failing over to the next one on connection errors,
+// timeouts and 5xx responses. Options apply to every endpoint.
+func NewMultiClient(codec *codec.Codec, endpoints []Endpoint, opts ...Option) Client {
+	// building the terra codec is expensive, so the endpoints share one
+	if codec == nil {
+		codec = terraapp.MakeCodec()
+	}
+
+	c := multiClient{codec: codec, logger: logger.New("http/multi")}
+	for _, endpoint := range endpoints {
+		lcd := New(codec, endpoint.URL, opts...).(client)
+		c.endpoints = append(c.endpoints, endpointClient{
+			Client:  lcd,
+			url:     endpoint.URL,
+			timeout: endpoint.Timeout,
+			breaker: lcd.breaker,
+			stats:   &endpointStats{},
+		})
+	}
+	return c
+}
+
+func (c multiClient) Codec() *codec.Codec { return c.codec }
+
+func (c multiClient) Request(payload RequestPayload) (*http.Response, error) {
+	var resp *http.Response
+	err := c.each(payload, func(endpoint endpointClient, payload RequestPayload, cancel context.CancelFunc) error {
+		r, err := endpoint.Request(payload)
+		if err != nil {
+			cancel()
+			return err
+		}
+		// the endpoint timeout has to outlive the call until the body is read
+		r.Body = cancelOnClose{ReadCloser: r.Body, cancel: cancel}
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+func (c multiClient) RequestJSON(payload RequestPayload, respBody interface{}) error {
+	return c.each(payload, func(endpoint endpointClient, payload RequestPayload, cancel context.CancelFunc) error {
+		defer cancel()
+		return endpoint.RequestJSON(payload, respBody)
+	})
+}
+
+func (c multiClient) each(
+	payload RequestPayload,
+	fn func(endpoint endpointClient, payload RequestPayload, cancel context.CancelFunc) error,
+) error {
+	if len(c.endpoints) == 0 {
+		return ErrNoEndpoints
+	}
+
+	var rawBody []byte
+	if payload.Body != nil {
+		var err error
+		if rawBody, err = ioutil.ReadAll(payload.Body); err != nil {
+			return errors.Wrap(err, "read request body")
+		}
+	}
+
+	parent := payload.Context
+	var lastErr error
+	for _, endpoint := range c.endpoints {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+		if endpoint.timeout > 0 {
+			ctx, cancel = context.WithTimeout(parent, endpoint.timeout)
+		} else {
+			ctx, cancel = context.WithCancel(parent)
+		}
+
+		attempt := payload
+		attempt.Context = ctx
+		if rawBody != nil {
+			attempt.Body = bytes.NewReader(rawBody)
+		}
+
+		lastErr = fn(endpoint, attempt, cancel)
+		if parent.Err() == nil && !errors.Is(lastErr, ErrCircuitOpen) {
+			// neither an abandoned nor a skipped request says anything about the endpoint
+			endpoint.stats.record(lastErr)
+		}
+		if lastErr == nil || !shouldFailover(parent, lastErr) {
+			return lastErr
+		}
+		c.logger.Warn("endpoint {} failed, trying next one. err={}", endpoint.url, lastErr)
+	}
+	return errors.Wrap(lastErr, "all endpoints failed")
+}
+
+// shouldFailover tells endpoint failures apart from errors another endpoint would answer the same.
+func shouldFailover(parent context.Context, err error) bool {
+	if parent.Err() != nil {
+		return false
+	}
+	return isEndpointFailure(err)
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r cancelOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.cancel()
+	return err
+}
@@ -0,0 +1,113 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tj/assert"
+)
+
+func TestMultiClientEndpointTimeout(t *testing.T) {
+	newServer := func(name string, delay time.Duration, calls *int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(calls, 1)
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"served_by":"` + name + `"}`))
+		}))
+	}
+
+	var slowCalls, fastCalls int32
+	slow := newServer("slow", 100*time.Millisecond, &slowCalls)
+	defer slow.Close()
+	fast := newServer("fast", 0, &fastCalls)
+	defer fast.Close()
+
+	request := func(client Client) string {
+		var body struct {
+			ServedBy string `json:"served_by"`
+		}
+		assert.NoError(t, client.RequestJSON(RequestPayload{
+			Context: context.Background(),
+			Method:  http.MethodGet,
+			Path:    "/node_info",
+		}, &body))
+		return body.ServedBy
+	}
+
+	// the archive node gets enough time to answer
+	client := NewMultiClient(nil, []Endpoint{
+		{URL: slow.URL, Timeout: time.Second},
+		{URL: fast.URL, Timeout: time.Second},
+	}, WithRetry(0, 0))
+	assert.Equal(t, "slow", request(client))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&fastCalls))
+
+	// a short timeout on the first node fails over to the next one
+	client = NewMultiClient(nil, []Endpoint{
+		{URL: slow.URL, Timeout: 20 * time.Millisecond},
+		{URL: fast.URL, Timeout: time.Second},
+	}, WithRetry(0, 0))
+	assert.Equal(t, "fast", request(client))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&slowCalls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fastCalls))
+}
+
+func TestMultiClientHealthSnapshot(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer up.Close()
+
+	client := NewMultiClient(nil, []Endpoint{{URL: down.URL}, {URL: up.URL}},
+		WithRetry(0, 0),
+		WithCircuitBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute}),
+	)
+	for i := 0; i < 3; i++ {
+		var body struct{}
+		assert.NoError(t, client.RequestJSON(RequestPayload{
+			Context: context.Background(),
+			Method:  http.MethodGet,
+			Path:    "/node_info",
+		}, &body))
+	}
+
+	snapshot := client.(HealthReporter).HealthSnapshot()
+	assert.Len(t, snapshot, 2)
+
+	// the third request skipped the open circuit
+	assert.Equal(t, down.URL, snapshot[0].URL)
+	assert.Equal(t, BreakerOpen, snapshot[0].State)
+	assert.False(t, snapshot[0].Healthy)
+	assert.Equal(t, 2, snapshot[0].Requests)
+	assert.Equal(t, 0.0, snapshot[0].SuccessRate)
+	assert.Equal(t, http.StatusBadGateway, StatusCode(snapshot[0].LastError))
+	assert.False(t, snapshot[0].LastErrorAt.IsZero())
+
+	assert.Equal(t, BreakerClosed, snapshot[1].State)
+	assert.True(t, snapshot[1].Healthy)
+	assert.Equal(t, 3, snapshot[1].Requests)
+	assert.Equal(t, 1.0, snapshot[1].SuccessRate)
+	assert.NoError(t, snapshot[1].LastError)
+}
+
+func TestMultiClientSharedCodec(t *testing.T) {
+	c := NewMultiClient(nil, []Endpoint{{URL: "http://a"}, {URL: "http://b"}}).(multiClient)
+	assert.NotNil(t, c.Codec())
+	for _, endpoint := range c.endpoints {
+		assert.True(t, c.Codec() == endpoint.Codec())
+	}
+}
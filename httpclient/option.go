@@ -0,0 +1,137 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Option func(*client)
+
+// WithHost overrides the Host header and TLS server name, e.g. to reach a virtual host by IP.
+func WithHost(host string) Option {
+	return func(c *client) {
+		c.host = host
+		if c.transport.TLSClientConfig == nil {
+			c.transport.TLSClientConfig = &tls.Config{}
+		}
+		c.transport.TLSClientConfig.ServerName = host
+	}
+}
+
+// WithHeader sets a header on every request, e.g. the API key of a node provider.
+func WithHeader(key, value string) Option {
+	return func(c *client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		c.headers.Set(key, value)
+	}
+}
+
+// WithDialTimeout bounds establishing the TCP connection only, so a dead endpoint fails fast
+// even when the request itself is allowed to take long.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.transport.DialContext = (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+}
+
+// WithDeduplication makes concurrent identical GET requests share a single round trip.
+// The shared request runs with the context of whichever caller issued it first.
+func WithDeduplication() Option {
+	return func(c *client) {
+		c.inflight = newInflightGroup()
+	}
+}
+
+// WithMaxConcurrency caps the number of in-flight requests across all goroutines sharing the client.
+// Requests over the limit wait for a free slot or for their context to end. n <= 0 means no limit.
+func WithMaxConcurrency(n int) Option {
+	return func(c *client) {
+		c.sem = newSemaphore(n)
+	}
+}
+
+// WithRetry retries failed requests up to maxRetries times, waiting backoff × attempt in between.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *client) {
+		c.retry.maxRetries = maxRetries
+		c.retry.backoff = backoff
+		c.retry.configured = true
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy. Unless WithRetry is also given,
+// it enables DefaultMaxRetries retries with DefaultRetryBackoff.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *client) {
+		c.retry.policy = policy
+	}
+}
+
+// WithRetryBudget caps retries across the client at ratio × the requests made in the last window,
+// so that a broad outage doesn't get amplified by every request retrying. Retries beyond the
+// budget fail with the error of the last attempt.
+func WithRetryBudget(ratio float64, window time.Duration) Option {
+	return func(c *client) {
+		c.budget = newRetryBudget(ratio, window)
+	}
+}
+
+// WithSlowRequestHook calls fn whenever a request attempt takes longer than threshold,
+// whether it succeeded or not.
+func WithSlowRequestHook(threshold time.Duration, fn func(req RequestPayload, duration time.Duration)) Option {
+	return func(c *client) {
+		c.slowThreshold = threshold
+		c.slowHook = fn
+	}
+}
+
+// WithCircuitBreaker fails requests with ErrCircuitOpen, without reaching the endpoint,
+// once it has failed config.FailureThreshold times in a row. With NewMultiClient every endpoint
+// gets its own breaker, so an open one is skipped over.
+func WithCircuitBreaker(config BreakerConfig) Option {
+	return func(c *client) {
+		c.breaker = newCircuitBreaker(config)
+	}
+}
+
+// WithTracer starts a span per request, retries included, and propagates its
+// trace context to the endpoint in the request headers.
+func WithTracer(tracer Tracer) Option {
+	return func(c *client) {
+		c.tracer = tracer
+	}
+}
+
+// WithFieldAliases renames response fields of LCD forks before decoding, e.g. {"tx_hash": "txhash"}
+// maps from the fork's name to the standard one.
+func WithFieldAliases(aliases map[string]string) Option {
+	return func(c *client) {
+		c.aliases = aliases
+	}
+}
+
+// WithDebug dumps every request and response in full to w, e.g. os.Stderr, for local debugging.
+// The values of redactHeaders and DefaultRedactedHeaders are masked.
+func WithDebug(w io.Writer, redactHeaders ...string) Option {
+	return func(c *client) {
+		c.debug = w
+		c.redact = redactHeaders
+	}
+}
+
+// WithResponseValidator checks every successful response body, after field aliases, before it's
+// decoded, e.g. against a JSON schema to catch a node upgrade changing a response. An error from fn
+// fails the request with ErrInvalidResponse.
+func WithResponseValidator(fn func(path string, body []byte) error) Option {
+	return func(c *client) {
+		c.validator = fn
+	}
+}
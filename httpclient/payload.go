@@ -11,4 +11,34 @@ type RequestPayload struct {
 	Path    string
 	Query   map[string]string
 	Body    io.Reader
+
+	// Idempotent marks a non-GET request as safe to retry,
+	// e.g. broadcasting a signed tx whose sequence stops it from being applied twice.
+	Idempotent bool
+
+	// Height queries the state at a past block instead of the latest one.
+	// When zero, the height set on Context with AtHeight is used, if any.
+	Height int64
+}
+
+const HeightHeader = "x-cosmos-block-height"
+
+type heightKey struct{}
+
+// AtHeight makes every query issued with the returned context read the state at height,
+// so the existing service methods can be used for historical queries.
+// Nodes prune old state; querying a pruned height fails.
+func AtHeight(ctx context.Context, height int64) context.Context {
+	return context.WithValue(ctx, heightKey{}, height)
+}
+
+func (p RequestPayload) height() int64 {
+	if p.Height != 0 {
+		return p.Height
+	}
+	if p.Context == nil {
+		return 0
+	}
+	height, _ := p.Context.Value(heightKey{}).(int64)
+	return height
 }
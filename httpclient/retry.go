@@ -0,0 +1,38 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryBackoff = 500 * time.Millisecond
+)
+
+// RetryPolicy decides whether a failed request is retried.
+// statusCode is 0 when no response was received (e.g. connection errors).
+type RetryPolicy func(req RequestPayload, statusCode int, err error) bool
+
+// DefaultRetryPolicy retries GETs and idempotent requests on gateway errors and connection failures.
+func DefaultRetryPolicy(req RequestPayload, statusCode int, err error) bool {
+	if req.Method != http.MethodGet && !req.Idempotent {
+		return false
+	}
+
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case 0:
+		return err != nil && req.Context.Err() == nil
+	default:
+		return false
+	}
+}
+
+type retryConfig struct {
+	maxRetries int
+	backoff    time.Duration
+	policy     RetryPolicy
+	configured bool
+}
@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// semaphore caps the number of requests in flight. A nil semaphore never blocks.
+type semaphore chan struct{}
+
+// newSemaphore returns a semaphore of n slots, or a nil one for n <= 0.
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+	return make(semaphore, n)
+}
+
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// slotWaitError is a request that ended waiting for a free slot, before anything was sent.
+type slotWaitError struct {
+	err error
+}
+
+func (e *slotWaitError) Error() string { return "wait for a free request slot: " + e.err.Error() }
+func (e *slotWaitError) Unwrap() error { return e.err }
+
+// releaseOnClose holds the slot until the response body is closed, since the connection is busy until then.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
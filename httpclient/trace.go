@@ -0,0 +1,47 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tracer starts a span per request. It mirrors the parts of an OpenTelemetry tracer and
+// propagator the client needs, so that otel can be plugged in without the client depending on it.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+	// Inject writes the trace context of ctx into header, e.g. as W3C traceparent.
+	Inject(ctx context.Context, header http.Header)
+}
+
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Span attribute keys, following the OpenTelemetry HTTP semantic conventions.
+const (
+	AttributeHTTPMethod     = "http.method"
+	AttributeHTTPTarget     = "http.target"
+	AttributeHTTPStatusCode = "http.status_code"
+)
+
+func (c client) startSpan(payload RequestPayload) (RequestPayload, Span) {
+	ctx, span := c.tracer.Start(payload.Context, "HTTP "+payload.Method)
+	span.SetAttribute(AttributeHTTPMethod, payload.Method)
+	span.SetAttribute(AttributeHTTPTarget, payload.Path)
+	payload.Context = ctx
+	return payload, span
+}
+
+func endSpan(span Span, resp *http.Response, err error) {
+	if err != nil {
+		if code := StatusCode(err); code != 0 {
+			span.SetAttribute(AttributeHTTPStatusCode, code)
+		}
+		span.RecordError(err)
+	} else {
+		span.SetAttribute(AttributeHTTPStatusCode, resp.StatusCode)
+	}
+	span.End()
+}
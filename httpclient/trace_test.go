@@ -0,0 +1,89 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+type spanKey struct{}
+
+type fakeSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *fakeSpan) RecordError(err error)                      { s.err = err }
+func (s *fakeSpan) End()                                       { s.ended = true }
+
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	span := &fakeSpan{name: name, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (t *fakeTracer) Inject(ctx context.Context, header http.Header) {
+	if span, ok := ctx.Value(spanKey{}).(*fakeSpan); ok {
+		header.Set("traceparent", span.name)
+	}
+}
+
+func TestTracer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "HTTP GET", r.Header.Get("traceparent"))
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	tracer := &fakeTracer{}
+	client := New(nil, server.URL, WithTracer(tracer))
+
+	var body struct{}
+	assert.NoError(t, client.RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/blocks/latest",
+	}, &body))
+	assert.Error(t, client.RequestJSON(RequestPayload{
+		Context: context.Background(),
+		Method:  http.MethodGet,
+		Path:    "/missing",
+	}, &body))
+
+	assert.Len(t, tracer.spans, 2)
+
+	ok := tracer.spans[0]
+	assert.True(t, ok.ended)
+	assert.NoError(t, ok.err)
+	assert.Equal(t, map[string]interface{}{
+		AttributeHTTPMethod:     http.MethodGet,
+		AttributeHTTPTarget:     "/blocks/latest",
+		AttributeHTTPStatusCode: http.StatusOK,
+	}, ok.attributes)
+
+	missing := tracer.spans[1]
+	assert.True(t, missing.ended)
+	assert.Error(t, missing.err)
+	assert.Equal(t, http.StatusNotFound, missing.attributes[AttributeHTTPStatusCode])
+	assert.Equal(t, "/missing", missing.attributes[AttributeHTTPTarget])
+}
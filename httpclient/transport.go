@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/airbloc/logger"
-	"github.com/pkg/errors"
 )
 
 type logTransport struct {
@@ -36,7 +35,7 @@ func (t logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		if err != nil {
 			t.logger.Error("failed to read response body. err={}", err)
 		} else {
-			return nil, errors.New(string(rawBody))
+			return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(rawBody)}
 		}
 	}
 
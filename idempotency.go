@@ -0,0 +1,133 @@
+package terra
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+type IdempotencyRecord struct {
+	TxHash   string
+	Sequence uint64
+}
+
+// IdempotencyStore keeps IdempotencyRecords across restarts, e.g. in a database table keyed by key.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) (IdempotencyRecord, bool, error)
+	Put(ctx context.Context, key string, record IdempotencyRecord) error
+	Delete(ctx context.Context, key string) error
+}
+
+// IdempotentBroadcaster broadcasts each idempotency key at most once. The tx hash is recorded
+// in Store before broadcasting, so a broadcast retried after a crash finds the record and checks
+// the chain for the recorded tx instead of sending a second one. A tx the node rejects in CheckTx
+// never enters the mempool, so its record is deleted and the key can be broadcast again; after any
+// other broadcast error the record is kept, since the tx may have been sent.
+type IdempotentBroadcaster struct {
+	Client Client
+	Codec  *codec.Codec
+	Store  IdempotencyStore
+}
+
+// Broadcast sends tx signed with sequence under key. If key was broadcast before, it doesn't send
+// anything and returns the recorded tx from the chain, or ErrBroadcastPending if it isn't there yet.
+func (b IdempotentBroadcaster) Broadcast(
+	ctx context.Context,
+	key string,
+	tx terraauth.StdTx,
+	sequence uint64,
+	mode types.BroadcastMode,
+) (cosmostypes.TxResponse, error) {
+	record, found, err := b.Store.Get(ctx, key)
+	if err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrapf(err, "get record of %s", key)
+	}
+	if found {
+		resp, err := b.Client.Transaction().GetTxByHash(ctx, record.TxHash)
+		if err != nil {
+			if httpclient.IsNotFound(err) {
+				return cosmostypes.TxResponse{TxHash: record.TxHash}, errors.Wrapf(
+					ErrBroadcastPending,
+					"tx %s of %s with sequence %d",
+					record.TxHash, key, record.Sequence,
+				)
+			}
+			return cosmostypes.TxResponse{}, errors.Wrapf(err, "fetch tx %s", record.TxHash)
+		}
+		return resp, nil
+	}
+
+	hash, err := txHash(b.Codec, tx)
+	if err != nil {
+		return cosmostypes.TxResponse{}, err
+	}
+	record = IdempotencyRecord{TxHash: hash, Sequence: sequence}
+	if err := b.Store.Put(ctx, key, record); err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrapf(err, "put record of %s", key)
+	}
+
+	resp, err := b.Client.Transaction().BroadcastTx(ctx, tx, mode)
+	if err != nil {
+		// a failed tx of a ModeBlock broadcast has a height and is on chain, only a CheckTx rejection has none;
+		// after a transport error the tx may be in the mempool, so the record stays
+		if resp.Code != 0 && resp.Height == 0 {
+			if err := b.Store.Delete(ctx, key); err != nil {
+				return resp, errors.Wrapf(err, "delete record of rejected %s", key)
+			}
+		}
+		return resp, errors.Wrap(err, "broadcast tx")
+	}
+	return resp, nil
+}
+
+// txHash returns the hash tendermint indexes tx under.
+func txHash(cdc *codec.Codec, tx terraauth.StdTx) (string, error) {
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal tx")
+	}
+	return fmt.Sprintf("%X", tmhash.Sum(txBytes)), nil
+}
+
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+// NewMemoryIdempotencyStore keeps records in memory, so it only guards against retries within a process.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{records: map[string]IdempotencyRecord{}}
+}
+
+func (s *memoryIdempotencyStore) Get(_ context.Context, key string) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, found := s.records[key]
+	return record, found, nil
+}
+
+func (s *memoryIdempotencyStore) Put(_ context.Context, key string, record IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
@@ -0,0 +1,150 @@
+package terra
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+	"github.com/tj/assert"
+)
+
+func TestIdempotentBroadcasterAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	cdc := MakeCodec()
+	tx := terraauth.NewStdTx(nil, terraauth.StdFee{Gas: 200000}, nil, "payout #1")
+
+	hash, err := txHash(cdc, tx)
+	assert.NoError(t, err)
+
+	var (
+		broadcasts int32
+		committed  int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/txs" {
+			atomic.AddInt32(&broadcasts, 1)
+			_, _ = w.Write([]byte(`{"height":"0","txhash":"` + hash + `","code":0}`))
+			return
+		}
+		if r.URL.Path == "/txs/"+hash && atomic.LoadInt32(&committed) == 1 {
+			_, _ = w.Write([]byte(`{"height":"10","txhash":"` + hash + `","code":0}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	store := NewMemoryIdempotencyStore()
+	newBroadcaster := func() IdempotentBroadcaster {
+		return IdempotentBroadcaster{
+			Client: NewClient(httpclient.New(cdc, server.URL)),
+			Codec:  cdc,
+			Store:  store,
+		}
+	}
+
+	resp, err := newBroadcaster().Broadcast(ctx, "payout-1", tx, 3, types.ModeSync)
+	assert.NoError(t, err)
+	record, found, err := store.Get(ctx, "payout-1")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, resp.TxHash, record.TxHash)
+	assert.Equal(t, uint64(3), record.Sequence)
+
+	// restarted before the tx is committed
+	resp, err = newBroadcaster().Broadcast(ctx, "payout-1", tx, 3, types.ModeSync)
+	assert.True(t, errors.Is(err, ErrBroadcastPending))
+	assert.Equal(t, record.TxHash, resp.TxHash)
+
+	// restarted after the tx is committed
+	atomic.StoreInt32(&committed, 1)
+	resp, err = newBroadcaster().Broadcast(ctx, "payout-1", tx, 3, types.ModeSync)
+	assert.NoError(t, err)
+	assert.Equal(t, record.TxHash, resp.TxHash)
+	assert.Equal(t, int64(10), resp.Height)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&broadcasts))
+}
+
+func TestIdempotentBroadcasterRetryAfterRejection(t *testing.T) {
+	ctx := context.Background()
+	cdc := MakeCodec()
+	tx := terraauth.NewStdTx(nil, terraauth.StdFee{Gas: 200000}, nil, "payout #2")
+
+	hash, err := txHash(cdc, tx)
+	assert.NoError(t, err)
+
+	var broadcasts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/txs" {
+			// the first attempt fails CheckTx with insufficient fee
+			if atomic.AddInt32(&broadcasts, 1) == 1 {
+				_, _ = w.Write([]byte(`{"height":"0","txhash":"` + hash + `","code":13,"raw_log":"insufficient fee"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"height":"0","txhash":"` + hash + `","code":0}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	store := NewMemoryIdempotencyStore()
+	broadcaster := IdempotentBroadcaster{
+		Client: NewClient(httpclient.New(cdc, server.URL)),
+		Codec:  cdc,
+		Store:  store,
+	}
+
+	resp, err := broadcaster.Broadcast(ctx, "payout-2", tx, 4, types.ModeSync)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient fee")
+	assert.Equal(t, uint32(13), resp.Code)
+	_, found, err := store.Get(ctx, "payout-2")
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	resp, err = broadcaster.Broadcast(ctx, "payout-2", tx, 4, types.ModeSync)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), resp.Code)
+	record, found, err := store.Get(ctx, "payout-2")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, hash, record.TxHash)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&broadcasts))
+}
+
+func TestIdempotentBroadcasterKeepsRecordOnTransportError(t *testing.T) {
+	ctx := context.Background()
+	cdc := MakeCodec()
+	tx := terraauth.NewStdTx(nil, terraauth.StdFee{Gas: 200000}, nil, "payout #3")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the node may have taken the tx before failing the response
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := NewMemoryIdempotencyStore()
+	broadcaster := IdempotentBroadcaster{
+		Client: NewClient(httpclient.New(cdc, server.URL)),
+		Codec:  cdc,
+		Store:  store,
+	}
+
+	_, err := broadcaster.Broadcast(ctx, "payout-3", tx, 5, types.ModeSync)
+	assert.Error(t, err)
+	_, found, err := store.Get(ctx, "payout-3")
+	assert.NoError(t, err)
+	assert.True(t, found)
+}
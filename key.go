@@ -20,6 +20,16 @@ type Key interface {
 	MakeSignature(msg terraauth.StdSignMsg) (terraauth.StdSignature, error)
 }
 
+// KeyAccAddress formats the account address of key with the prefix of client.
+func KeyAccAddress(client Client, key Key) (string, error) {
+	return client.AddressPrefix().FormatAccAddress(key.AccAddress())
+}
+
+// KeyValAddress formats the operator address of key with the prefix of client.
+func KeyValAddress(client Client, key Key) (string, error) {
+	return client.AddressPrefix().FormatValAddress(key.ValAddress())
+}
+
 type rawKey struct {
 	privKey secp256k1.PrivKeySecp256k1
 }
@@ -0,0 +1,89 @@
+package terra
+
+import (
+	"io/ioutil"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/mintkey"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// keystoreAlgo is the key type recorded in the armor header, as the cosmos keyring writes it.
+const keystoreAlgo = "secp256k1"
+
+// Keystore encrypts private keys into ASCII-armored files the way `terracli keys export` does,
+// with a bcrypt-derived key, so they can be imported from and exported to the CLI.
+type Keystore struct{}
+
+func (Keystore) Encrypt(priv crypto.PrivKey, passphrase string) ([]byte, error) {
+	if priv == nil {
+		return nil, errors.New("no private key to encrypt")
+	}
+	return []byte(mintkey.EncryptArmorPrivKey(priv, passphrase, keystoreAlgo)), nil
+}
+
+func (Keystore) Decrypt(data []byte, passphrase string) (crypto.PrivKey, error) {
+	priv, _, err := mintkey.UnarmorDecryptPrivKey(string(data), passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "unarmor and decrypt private key")
+	}
+	return priv, nil
+}
+
+// keystoreKey decrypts its keystore on every signature rather than keeping the private key in memory.
+type keystoreKey struct {
+	data       []byte
+	passphrase string
+	pubKey     crypto.PubKey
+}
+
+// NewKeystoreKey reads a keystore file, decrypting it once to check passphrase.
+func NewKeystoreKey(path, passphrase string) (Key, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read keystore file")
+	}
+
+	priv, err := Keystore{}.Decrypt(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return keystoreKey{data: data, passphrase: passphrase, pubKey: priv.PubKey()}, nil
+}
+
+func (k keystoreKey) AccAddress() cosmostypes.AccAddress { return k.pubKey.Address().Bytes() }
+func (k keystoreKey) ValAddress() cosmostypes.ValAddress { return k.pubKey.Address().Bytes() }
+func (k keystoreKey) PubKey() crypto.PubKey              { return k.pubKey }
+
+func (k keystoreKey) SignTx(msg terraauth.StdSignMsg) (terraauth.StdTx, error) {
+	sign, err := k.MakeSignature(msg)
+	if err != nil {
+		return terraauth.StdTx{}, errors.Wrap(err, "make signature")
+	}
+
+	signedTx := terraauth.NewStdTx(
+		msg.Msgs,
+		msg.Fee,
+		[]terraauth.StdSignature{sign},
+		msg.Memo,
+	)
+	return signedTx, nil
+}
+
+func (k keystoreKey) MakeSignature(msg terraauth.StdSignMsg) (terraauth.StdSignature, error) {
+	priv, err := Keystore{}.Decrypt(k.data, k.passphrase)
+	if err != nil {
+		return terraauth.StdSignature{}, err
+	}
+
+	sign, err := priv.Sign(msg.Bytes())
+	if err != nil {
+		return terraauth.StdSignature{}, errors.Wrap(err, "sign with keystore key")
+	}
+	return terraauth.StdSignature{
+		PubKey:    k.pubKey,
+		Signature: sign,
+	}, nil
+}
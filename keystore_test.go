@@ -0,0 +1,55 @@
+package terra
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestKeystoreKey(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+
+	data, err := Keystore{}.Encrypt(priv, "correct horse")
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "BEGIN TENDERMINT PRIVATE KEY")
+
+	decrypted, err := Keystore{}.Decrypt(data, "correct horse")
+	assert.NoError(t, err)
+	assert.True(t, priv.Equals(decrypted))
+	_, err = Keystore{}.Decrypt(data, "wrong")
+	assert.Error(t, err)
+
+	dir, err := ioutil.TempDir("", "keystore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "operator.armor")
+	assert.NoError(t, ioutil.WriteFile(path, data, 0600))
+
+	_, err = NewKeystoreKey(path, "wrong")
+	assert.Error(t, err)
+	key, err := NewKeystoreKey(path, "correct horse")
+	assert.NoError(t, err)
+	assert.Equal(t, cosmostypes.AccAddress(priv.PubKey().Address()), key.AccAddress())
+
+	signMsg := terraauth.StdSignMsg{
+		ChainID:       "bombay-12",
+		AccountNumber: 7,
+		Sequence:      3,
+		Fee:           terraauth.StdFee{Gas: 200000},
+		Msgs: []cosmostypes.Msg{terrabank.NewMsgSend(
+			key.AccAddress(),
+			cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+			cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 1000)),
+		)},
+	}
+	tx, err := key.SignTx(signMsg)
+	assert.NoError(t, err)
+	assert.True(t, priv.PubKey().VerifyBytes(signMsg.Bytes(), tx.Signatures[0].Signature))
+}
@@ -0,0 +1,93 @@
+package kmssigner
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	terra "github.com/cawabunga/terra.go"
+
+	"github.com/btcsuite/btcd/btcec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// SignFunc signs a sha256 digest with a secp256k1 key held elsewhere, e.g. AWS or GCP KMS.
+// The signature may be ASN.1 DER (as KMS returns it) or 64 bytes of R || S.
+type SignFunc func(digest []byte) ([]byte, error)
+
+type kmsSigner struct {
+	pubKey secp256k1.PubKeySecp256k1
+	sign   SignFunc
+}
+
+// New returns a terra.Key that never sees the private key. pubKey is the compressed public key of the KMS key.
+func New(pubKey secp256k1.PubKeySecp256k1, sign SignFunc) terra.Key {
+	return kmsSigner{pubKey: pubKey, sign: sign}
+}
+
+func (k kmsSigner) AccAddress() cosmostypes.AccAddress { return k.pubKey.Address().Bytes() }
+func (k kmsSigner) ValAddress() cosmostypes.ValAddress { return k.pubKey.Address().Bytes() }
+func (k kmsSigner) PubKey() crypto.PubKey              { return k.pubKey }
+
+func (k kmsSigner) SignTx(msg terraauth.StdSignMsg) (terraauth.StdTx, error) {
+	sign, err := k.MakeSignature(msg)
+	if err != nil {
+		return terraauth.StdTx{}, errors.Wrap(err, "make signature")
+	}
+
+	signedTx := terraauth.NewStdTx(
+		msg.Msgs,
+		msg.Fee,
+		[]terraauth.StdSignature{sign},
+		msg.Memo,
+	)
+	return signedTx, nil
+}
+
+func (k kmsSigner) MakeSignature(msg terraauth.StdSignMsg) (terraauth.StdSignature, error) {
+	signBytes := msg.Bytes()
+	digest := sha256.Sum256(signBytes)
+
+	raw, err := k.sign(digest[:])
+	if err != nil {
+		return terraauth.StdSignature{}, errors.Wrap(err, "sign with kms")
+	}
+	sig, err := normalizeSignature(raw)
+	if err != nil {
+		return terraauth.StdSignature{}, errors.Wrap(err, "normalize signature")
+	}
+	if !k.pubKey.VerifyBytes(signBytes, sig) {
+		return terraauth.StdSignature{}, terra.ErrInvalidSignature
+	}
+	return terraauth.StdSignature{
+		PubKey:    k.pubKey,
+		Signature: sig,
+	}, nil
+}
+
+// normalizeSignature converts raw into the 64-byte R || S form with a low S, which tendermint requires.
+func normalizeSignature(raw []byte) ([]byte, error) {
+	var r, s *big.Int
+	if len(raw) == 64 {
+		r, s = new(big.Int).SetBytes(raw[:32]), new(big.Int).SetBytes(raw[32:])
+	} else {
+		parsed, err := btcec.ParseDERSignature(raw, btcec.S256())
+		if err != nil {
+			return nil, errors.Wrap(err, "parse der signature")
+		}
+		r, s = parsed.R, parsed.S
+	}
+
+	curveOrder := btcec.S256().N
+	if s.Cmp(new(big.Int).Rsh(curveOrder, 1)) > 0 {
+		s = new(big.Int).Sub(curveOrder, s)
+	}
+
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return sig, nil
+}
@@ -0,0 +1,54 @@
+package kmssigner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestKMSSigner(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	assert.NoError(t, err)
+
+	var pubKey secp256k1.PubKeySecp256k1
+	copy(pubKey[:], privKey.PubKey().SerializeCompressed())
+
+	// fake KMS answering in DER with a high S, which KMS doesn't normalize either
+	fakeKMS := func(digest []byte) ([]byte, error) {
+		sig, err := privKey.Sign(digest)
+		if err != nil {
+			return nil, err
+		}
+		sig.S = new(big.Int).Sub(btcec.S256().N, sig.S)
+		return sig.Serialize(), nil
+	}
+
+	signer := New(pubKey, fakeKMS)
+	signMsg := terraauth.StdSignMsg{
+		ChainID:       "bombay-12",
+		AccountNumber: 1,
+		Sequence:      2,
+		Fee: terraauth.StdFee{
+			Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 30000)),
+			Gas:    200000,
+		},
+		Msgs: []cosmostypes.Msg{terrabank.MsgSend{
+			FromAddress: signer.AccAddress(),
+			ToAddress:   signer.AccAddress(),
+			Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+		}},
+	}
+
+	tx, err := signer.SignTx(signMsg)
+	assert.NoError(t, err)
+	assert.Len(t, tx.Signatures, 1)
+	assert.Len(t, tx.Signatures[0].Signature, 64)
+	assert.True(t, pubKey.VerifyBytes(signMsg.Bytes(), tx.Signatures[0].Signature))
+	assert.Equal(t, cosmostypes.AccAddress(pubKey.Address()), signer.AccAddress())
+}
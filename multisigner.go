@@ -0,0 +1,106 @@
+package terra
+
+import (
+	"context"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// TxSigner is one of the accounts signing a multi-signer tx, each with its own account number and sequence.
+type TxSigner struct {
+	Key           Key
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+// SignMultiSignerTx signs a tx whose msgs come from different accounts, e.g. the two legs of an atomic swap.
+// Signatures are ordered like StdTx.GetSigners, i.e. by first appearance in msgs,
+// and the first signer pays the fee. Each signer signs with its own account number and sequence.
+func SignMultiSignerTx(
+	chainId string,
+	fee terraauth.StdFee,
+	msgs []cosmostypes.Msg,
+	memo string,
+	signers []TxSigner,
+) (terraauth.StdTx, error) {
+	byAddress := make(map[string]TxSigner, len(signers))
+	for _, signer := range signers {
+		byAddress[signer.Key.AccAddress().String()] = signer
+	}
+
+	tx := terraauth.NewStdTx(msgs, fee, nil, memo)
+	for _, addr := range tx.GetSigners() {
+		signer, ok := byAddress[addr.String()]
+		if !ok {
+			return terraauth.StdTx{}, errors.Wrap(ErrMissingSigner, addr.String())
+		}
+
+		sign, err := signer.Key.MakeSignature(terraauth.StdSignMsg{
+			ChainID:       chainId,
+			AccountNumber: signer.AccountNumber,
+			Sequence:      signer.Sequence,
+			Fee:           fee,
+			Msgs:          msgs,
+			Memo:          memo,
+		})
+		if err != nil {
+			return terraauth.StdTx{}, errors.Wrapf(err, "sign as %s", addr.String())
+		}
+		tx.Signatures = append(tx.Signatures, sign)
+	}
+	return tx, nil
+}
+
+// CreateAndSignMultiSignerTx fetches the account of every key, estimates the fee paid by the first
+// required signer and signs the tx with all of them.
+func CreateAndSignMultiSignerTx(
+	ctx context.Context,
+	client Client,
+	keys []Key,
+	opts CreateTxOptions,
+) (terraauth.StdTx, error) {
+	nodeInfo, err := client.Tendermint().GetNodeInfo(ctx)
+	if err != nil {
+		return terraauth.StdTx{}, errors.Wrap(err, "fetch node info")
+	}
+
+	signers := make([]TxSigner, 0, len(keys))
+	for _, key := range keys {
+		accInfo, err := client.Auth().GetAccountInfo(ctx, key.AccAddress())
+		if err != nil {
+			return terraauth.StdTx{}, errors.Wrapf(err, "fetch account info of %s", key.AccAddress().String())
+		}
+		signers = append(signers, TxSigner{
+			Key:           key,
+			AccountNumber: accInfo.GetAccountNumber(),
+			Sequence:      accInfo.GetSequence(),
+		})
+	}
+
+	feePayers := terraauth.NewStdTx(opts.Msgs, terraauth.StdFee{}, nil, "").GetSigners()
+	if len(feePayers) == 0 {
+		return terraauth.StdTx{}, errors.New("msgs have no signers")
+	}
+	var payer *TxSigner
+	for index := range signers {
+		if signers[index].Key.AccAddress().Equals(feePayers[0]) {
+			payer = &signers[index]
+			break
+		}
+	}
+	if payer == nil {
+		return terraauth.StdTx{}, errors.Wrap(ErrMissingSigner, feePayers[0].String())
+	}
+
+	signMsg, err := createSignMsg(
+		ctx, client, nodeInfo.Network,
+		payer.AccountNumber, payer.Sequence, payer.Key.AccAddress(),
+		opts,
+	)
+	if err != nil {
+		return terraauth.StdTx{}, errors.Wrap(err, "create sign msg")
+	}
+	return SignMultiSignerTx(signMsg.ChainID, signMsg.Fee, signMsg.Msgs, signMsg.Memo, signers)
+}
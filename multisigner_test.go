@@ -0,0 +1,59 @@
+package terra
+
+import (
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestSignMultiSignerTx(t *testing.T) {
+	alice := NewRawKey("a96e62ed3955e65be32703f12d87b6b5cf26039ecfa948dc5107a495418e5330")
+	bob := NewRawKey("5f2d9a4a947b1d6c4e15b1f2ec8e1365f7d1b6a3e7e6a7f4f3b0c0a9c8e7d6b5")
+
+	fee := terraauth.StdFee{
+		Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 30000)),
+		Gas:    300000,
+	}
+	msgs := []cosmostypes.Msg{
+		terrabank.MsgSend{
+			FromAddress: alice.AccAddress(),
+			ToAddress:   bob.AccAddress(),
+			Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+		},
+		terrabank.MsgSend{
+			FromAddress: bob.AccAddress(),
+			ToAddress:   alice.AccAddress(),
+			Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("ukrw", 1200000000)),
+		},
+	}
+	signers := []TxSigner{
+		{Key: bob, AccountNumber: 20, Sequence: 3},
+		{Key: alice, AccountNumber: 10, Sequence: 7},
+	}
+
+	tx, err := SignMultiSignerTx("bombay-12", fee, msgs, "swap", signers)
+	assert.NoError(t, err)
+	assert.NoError(t, tx.ValidateBasic())
+	assert.Len(t, tx.Signatures, 2)
+
+	// ordered by the msgs, not by the given signers
+	expected := []TxSigner{signers[1], signers[0]}
+	for index, signer := range expected {
+		signBytes := terraauth.StdSignMsg{
+			ChainID:       "bombay-12",
+			AccountNumber: signer.AccountNumber,
+			Sequence:      signer.Sequence,
+			Fee:           fee,
+			Msgs:          msgs,
+			Memo:          "swap",
+		}.Bytes()
+		assert.Equal(t, signer.Key.PubKey(), tx.Signatures[index].PubKey)
+		assert.True(t, signer.Key.PubKey().VerifyBytes(signBytes, tx.Signatures[index].Signature))
+	}
+
+	_, err = SignMultiSignerTx("bombay-12", fee, msgs, "swap", signers[:1])
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package terra
+
+import (
+	"context"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraassets "github.com/terra-project/core/types/assets"
+)
+
+// GetAccountNetWorth sums the spendable balance, delegations, unbonding entries and pending rewards
+// of address, valued in quoteDenom.
+//
+// Each denom is converted at the current oracle exchange rates through LUNA as the pivot,
+// i.e. amount / rate(denom) × rate(quoteDenom), without accounting for swap spread or tobin tax.
+// Locked vesting tokens aren't counted, and delegations are valued after slashing.
+// Denoms the oracle doesn't price (e.g. IBC or CW20 tokens) fail with ErrNoExchangeRate.
+func GetAccountNetWorth(
+	ctx context.Context,
+	client Client,
+	address cosmostypes.AccAddress,
+	quoteDenom string,
+) (cosmostypes.Dec, error) {
+	var holdings cosmostypes.DecCoins
+
+	spendable, err := client.Bank().GetSpendableBalances(ctx, address)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch spendable balances")
+	}
+	holdings = holdings.Add(cosmostypes.NewDecCoinsFromCoins(spendable...)...)
+
+	delegations, err := client.Staking().GetDelegationBalances(ctx, address)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch delegation balances")
+	}
+	for _, delegation := range delegations {
+		holdings = holdings.Add(cosmostypes.NewDecCoinFromCoin(delegation.Balance))
+	}
+
+	unbondings, err := client.Staking().GetUnbondingDelegations(ctx, address)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch unbonding delegations")
+	}
+	if len(unbondings) > 0 {
+		params, err := client.Staking().GetParams(ctx)
+		if err != nil {
+			return cosmostypes.Dec{}, errors.Wrap(err, "fetch staking params")
+		}
+		for _, unbonding := range unbondings {
+			for _, entry := range unbonding.Entries {
+				holdings = holdings.Add(cosmostypes.NewDecCoin(params.BondDenom, entry.Balance))
+			}
+		}
+	}
+
+	rewards, err := client.Distribution().GetTotalRewards(ctx, address)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch rewards")
+	}
+	holdings = holdings.Add(rewards...)
+
+	rates, err := client.Oracle().GetExchangeRates(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch exchange rates")
+	}
+	return convertToQuote(holdings, rates, quoteDenom)
+}
+
+// convertToQuote values coins in quoteDenom, given the price of one LUNA in each denom.
+func convertToQuote(coins, lunaRates cosmostypes.DecCoins, quoteDenom string) (cosmostypes.Dec, error) {
+	rateOf := func(denom string) (cosmostypes.Dec, error) {
+		if denom == terraassets.MicroLunaDenom {
+			return cosmostypes.OneDec(), nil
+		}
+		if rate := lunaRates.AmountOf(denom); rate.IsPositive() {
+			return rate, nil
+		}
+		return cosmostypes.Dec{}, errors.Wrap(ErrNoExchangeRate, denom)
+	}
+
+	quoteRate, err := rateOf(quoteDenom)
+	if err != nil {
+		return cosmostypes.Dec{}, err
+	}
+
+	total := cosmostypes.ZeroDec()
+	for _, coin := range coins {
+		if coin.Denom == quoteDenom {
+			total = total.Add(coin.Amount)
+			continue
+		}
+		rate, err := rateOf(coin.Denom)
+		if err != nil {
+			return cosmostypes.Dec{}, err
+		}
+		total = total.Add(coin.Amount.Quo(rate).Mul(quoteRate))
+	}
+	return total, nil
+}
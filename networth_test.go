@@ -0,0 +1,64 @@
+package terra
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tj/assert"
+)
+
+func TestGetAccountNetWorth(t *testing.T) {
+	address := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	validator := cosmostypes.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	consPubKey := cosmostypes.MustBech32ifyPubKey(cosmostypes.Bech32PubKeyTypeConsPub, ed25519.GenPrivKey().PubKey())
+
+	routes := map[string]string{
+		"/cosmos/bank/v1beta1/spendable_balances/" + address.String(): `{"balances":[
+			{"denom":"uluna","amount":"1000000"},{"denom":"uusd","amount":"20000000"}
+		]}`,
+		"/staking/parameters": `{"height":"100","result":{
+			"unbonding_time":"1814400000000000","max_validators":100,"max_entries":7,
+			"historical_entries":0,"bond_denom":"uluna"
+		}}`,
+		"/staking/delegators/" + address.String() + "/delegations": fmt.Sprintf(`{"height":"100","result":[
+			{"delegator_address":"%s","validator_address":"%s","shares":"2000000.000000000000000000","balance":{"denom":"uluna","amount":"2000000"}}
+		]}`, address.String(), validator.String()),
+		"/staking/validators/" + validator.String(): fmt.Sprintf(`{"height":"100","result":{
+			"operator_address":"%s","consensus_pubkey":"%s","jailed":false,"status":2,
+			"tokens":"10000000","delegator_shares":"10000000.000000000000000000",
+			"description":{"moniker":"validator","identity":"","website":"","security_contact":"","details":""},
+			"unbonding_height":"0","unbonding_time":"1970-01-01T00:00:00Z",
+			"commission":{
+				"commission_rates":{"rate":"0.100000000000000000","max_rate":"0.200000000000000000","max_change_rate":"0.010000000000000000"},
+				"update_time":"2021-01-01T00:00:00Z"
+			},
+			"min_self_delegation":"1"
+		}}`, validator.String(), consPubKey),
+		"/staking/delegators/" + address.String() + "/unbonding_delegations": fmt.Sprintf(`{"height":"100","result":[
+			{"delegator_address":"%s","validator_address":"%s","entries":[
+				{"creation_height":"90","completion_time":"2021-07-01T00:00:00Z","initial_balance":"500000","balance":"500000"}
+			]}
+		]}`, address.String(), validator.String()),
+		"/distribution/delegators/" + address.String() + "/rewards": `{"height":"100","result":{
+			"rewards":[],
+			"total":[{"denom":"uluna","amount":"500000.000000000000000000"},{"denom":"ukrw","amount":"60000000.000000000000000000"}]
+		}}`,
+		"/oracle/denoms/exchange_rates": `{"height":"100","result":[
+			{"denom":"ukrw","amount":"60000.000000000000000000"},
+			{"denom":"uusd","amount":"50.000000000000000000"}
+		]}`,
+	}
+	client := newFakeClient(routes)
+
+	// 1 + 2 + 0.5 + 0.5 LUNA = 4 LUNA = 200 USD, 20 USD, 60 KRW = 0.001 LUNA = 0.05 USD
+	netWorth, err := GetAccountNetWorth(context.Background(), client, address, "uusd")
+	assert.NoError(t, err)
+	assert.Equal(t, "220050000.000000000000000000", netWorth.String())
+
+	_, err = GetAccountNetWorth(context.Background(), client, address, "ueur")
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package terra
+
+import (
+	"github.com/cawabunga/terra.go/service"
+	"github.com/cawabunga/terra.go/types"
+)
+
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	addressPrefix types.AddressPrefix
+	transaction   []service.TransactionOption
+}
+
+func WithTransactionOptions(opts ...service.TransactionOption) Option {
+	return func(o *clientOptions) {
+		o.transaction = append(o.transaction, opts...)
+	}
+}
+
+// WithAddressPrefix sets the bech32 prefixes returned by Client.AddressPrefix and used
+// for the addresses the services put in request paths and tx searches.
+// The global cosmos config is sealed with the terra prefixes on init, so the client re-encodes
+// the addresses in responses with the terra prefixes before decoding them: they decode, but
+// their String() is terra. Use KeyAccAddress and the prefix helpers rather than
+// AccAddress.String() to show them on such networks.
+func WithAddressPrefix(accountPrefix, valoperPrefix, consPrefix string) Option {
+	return func(o *clientOptions) {
+		o.addressPrefix = types.AddressPrefix{
+			Account:   accountPrefix,
+			Validator: valoperPrefix,
+			Consensus: consPrefix,
+		}
+	}
+}
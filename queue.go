@@ -0,0 +1,224 @@
+package terra
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/pkg/errors"
+)
+
+// SequenceManager hands out the sequences of an account one after another without waiting for
+// the previous tx to be committed. The LCD only reports the committed sequence, so it's fetched
+// once and then counted locally until Resync.
+type SequenceManager struct {
+	client Client
+	addr   cosmostypes.AccAddress
+
+	mu            sync.Mutex
+	synced        bool
+	accountNumber uint64
+	next          uint64
+}
+
+func NewSequenceManager(client Client, addr cosmostypes.AccAddress) *SequenceManager {
+	return &SequenceManager{client: client, addr: addr}
+}
+
+// Next returns the account number and reserves the next sequence.
+func (m *SequenceManager) Next(ctx context.Context) (accountNumber, sequence uint64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.synced {
+		if err := m.sync(ctx); err != nil {
+			return 0, 0, err
+		}
+	}
+	sequence = m.next
+	m.next++
+	return m.accountNumber, sequence, nil
+}
+
+// Release gives back sequence if it's the last one reserved, e.g. when its tx failed CheckTx and
+// so didn't consume it.
+func (m *SequenceManager) Release(sequence uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.synced && m.next == sequence+1 {
+		m.next = sequence
+	}
+}
+
+// Resync drops the local count and refetches the sequence from the chain.
+func (m *SequenceManager) Resync(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.synced = false
+	return m.sync(ctx)
+}
+
+func (m *SequenceManager) sync(ctx context.Context) error {
+	accInfo, err := m.client.Auth().GetAccountInfo(ctx, m.addr)
+	if err != nil {
+		return errors.Wrapf(err, "fetch account info of %s", m.addr.String())
+	}
+	m.accountNumber = accInfo.GetAccountNumber()
+	m.next = accInfo.GetSequence()
+	m.synced = true
+	return nil
+}
+
+type BroadcastResult struct {
+	Sequence uint64
+	Response cosmostypes.TxResponse
+	Err      error
+}
+
+type BroadcastQueueOptions struct {
+	// Size is how many txs can wait in the queue before Enqueue blocks.
+	Size int
+	// Mode defaults to types.ModeSync, which returns as soon as the tx is in the mempool.
+	Mode types.BroadcastMode
+}
+
+type queuedTx struct {
+	ctx    context.Context
+	opts   CreateTxOptions
+	result chan BroadcastResult
+}
+
+// BroadcastQueue signs and broadcasts enqueued txs of one key one at a time, in the order they
+// were enqueued, so every tx takes the sequence right after the previous one. A tx rejected for a
+// wrong sequence is signed again once with the sequence resynced from the chain.
+type BroadcastQueue struct {
+	client    Client
+	key       Key
+	chainId   string
+	mode      types.BroadcastMode
+	sequences *SequenceManager
+
+	mu     sync.RWMutex
+	closed bool
+	txs    chan queuedTx
+	done   chan struct{}
+}
+
+func NewBroadcastQueue(ctx context.Context, client Client, key Key, opts BroadcastQueueOptions) (*BroadcastQueue, error) {
+	nodeInfo, err := client.Tendermint().GetNodeInfo(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch node info")
+	}
+	if opts.Mode == "" {
+		opts.Mode = types.ModeSync
+	}
+
+	q := &BroadcastQueue{
+		client:    client,
+		key:       key,
+		chainId:   nodeInfo.Network,
+		mode:      opts.Mode,
+		sequences: NewSequenceManager(client, key.AccAddress()),
+		txs:       make(chan queuedTx, opts.Size),
+		done:      make(chan struct{}),
+	}
+	go q.run()
+	return q, nil
+}
+
+// Enqueue adds a tx to the queue, blocking while the queue is full. The returned channel receives
+// the result once the tx is broadcast. Fee estimation and signing happen when the tx's turn comes.
+func (q *BroadcastQueue) Enqueue(ctx context.Context, opts CreateTxOptions) (<-chan BroadcastResult, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return nil, ErrQueueClosed
+	}
+
+	tx := queuedTx{ctx: ctx, opts: opts, result: make(chan BroadcastResult, 1)}
+	select {
+	case q.txs <- tx:
+		return tx.result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close stops accepting txs and waits until the queued ones are broadcast.
+func (q *BroadcastQueue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.txs)
+	}
+	q.mu.Unlock()
+
+	<-q.done
+}
+
+func (q *BroadcastQueue) run() {
+	defer close(q.done)
+
+	for tx := range q.txs {
+		tx.result <- q.broadcast(tx.ctx, tx.opts)
+	}
+}
+
+func (q *BroadcastQueue) broadcast(ctx context.Context, opts CreateTxOptions) BroadcastResult {
+	result := q.signAndBroadcast(ctx, opts)
+	if !isSequenceMismatch(result) {
+		return result
+	}
+
+	if err := q.sequences.Resync(ctx); err != nil {
+		result.Err = errors.Wrap(err, "resync sequence")
+		return result
+	}
+	return q.signAndBroadcast(ctx, opts)
+}
+
+func (q *BroadcastQueue) signAndBroadcast(ctx context.Context, opts CreateTxOptions) BroadcastResult {
+	accountNumber, sequence, err := q.sequences.Next(ctx)
+	if err != nil {
+		return BroadcastResult{Err: errors.Wrap(err, "reserve sequence")}
+	}
+
+	result := BroadcastResult{Sequence: sequence}
+	signMsg, err := createSignMsg(ctx, q.client, q.chainId, accountNumber, sequence, q.key.AccAddress(), opts)
+	if err != nil {
+		q.sequences.Release(sequence)
+		result.Err = errors.Wrap(err, "create tx")
+		return result
+	}
+	tx, err := q.key.SignTx(signMsg)
+	if err != nil {
+		q.sequences.Release(sequence)
+		result.Err = errors.Wrap(err, "sign tx")
+		return result
+	}
+
+	result.Response, err = q.client.Transaction().BroadcastTx(ctx, tx, q.mode)
+	if err != nil {
+		// only a tx rejected by CheckTx, which has a code but no height, doesn't consume its sequence;
+		// after a transport error the tx may be in the mempool, so the sequence stays taken
+		if result.Response.Code != 0 && result.Response.Height == 0 {
+			q.sequences.Release(sequence)
+		}
+		result.Err = errors.Wrapf(err, "broadcast tx with sequence %d", sequence)
+	}
+	return result
+}
+
+// isSequenceMismatch tells if the ante handler rejected the tx, which on this chain version is
+// how a wrong sequence is reported.
+func isSequenceMismatch(result BroadcastResult) bool {
+	return result.Err != nil &&
+		result.Response.Codespace == sdkerrors.RootCodespace &&
+		result.Response.Code == sdkerrors.ErrUnauthorized.ABCICode()
+}
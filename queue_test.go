@@ -0,0 +1,195 @@
+package terra
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosauthrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
+	cosmosauth "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestBroadcastQueueOrder(t *testing.T) {
+	ctx := context.Background()
+	cdc := MakeCodec()
+	key := NewRawKey("a96e62ed3955e65be32703f12d87b6b5cf26039ecfa948dc5107a495418e5330")
+
+	var (
+		mu          sync.Mutex
+		expected    = uint64(3)
+		accountHits int32
+		broadcasts  []uint64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/node_info":
+			_, _ = w.Write([]byte(`{"node_info":{"network":"bombay-12"}}`))
+		case "/auth/accounts/" + key.AccAddress().String():
+			// the first read is behind the chain, so the first tx is rejected and resynced
+			sequence := expected
+			if atomic.AddInt32(&accountHits, 1) == 1 {
+				sequence = 1
+			}
+			bz, err := cdc.MarshalJSON(struct {
+				Height string             `json:"height"`
+				Result cosmosauth.Account `json:"result"`
+			}{Height: "100", Result: authtypes.NewBaseAccount(key.AccAddress(), nil, key.PubKey(), 7, sequence)})
+			assert.NoError(t, err)
+			_, _ = w.Write(bz)
+		case "/txs":
+			bz, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var req cosmosauthrest.BroadcastReq
+			assert.NoError(t, cdc.UnmarshalJSON(bz, &req))
+
+			signMsg := terraauth.StdSignMsg{
+				ChainID:       "bombay-12",
+				AccountNumber: 7,
+				Sequence:      expected,
+				Fee:           req.Tx.Fee,
+				Msgs:          req.Tx.Msgs,
+				Memo:          req.Tx.Memo,
+			}
+			if !key.PubKey().VerifyBytes(signMsg.Bytes(), req.Tx.Signatures[0].Signature) {
+				_, _ = w.Write([]byte(`{"height":"0","txhash":"","codespace":"sdk","code":4,"raw_log":"unauthorized: signature verification failed"}`))
+				return
+			}
+			broadcasts = append(broadcasts, expected)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"height":"0","txhash":"%X","code":0}`, expected)))
+			expected++
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	queue, err := NewBroadcastQueue(ctx, NewClient(httpclient.New(cdc, server.URL)), key, BroadcastQueueOptions{Size: 1})
+	assert.NoError(t, err)
+
+	fee := terraauth.StdFee{Gas: 200000}
+	results := make([]<-chan BroadcastResult, 3)
+	for index := range results {
+		results[index], err = queue.Enqueue(ctx, CreateTxOptions{
+			Msgs: []cosmostypes.Msg{terrabank.MsgSend{
+				FromAddress: key.AccAddress(),
+				ToAddress:   cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+				Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+			}},
+			Fee:  &fee,
+			Memo: fmt.Sprintf("payout #%d", index),
+		})
+		assert.NoError(t, err)
+	}
+	queue.Close()
+
+	for index, result := range results {
+		r := <-result
+		assert.NoError(t, r.Err)
+		assert.Equal(t, uint64(3+index), r.Sequence)
+	}
+	assert.Equal(t, []uint64{3, 4, 5}, broadcasts)
+
+	_, err = queue.Enqueue(ctx, CreateTxOptions{})
+	assert.Equal(t, ErrQueueClosed, err)
+}
+
+func TestBroadcastQueueKeepsSequenceOnTransportError(t *testing.T) {
+	ctx := context.Background()
+	cdc := MakeCodec()
+	key := NewRawKey("a96e62ed3955e65be32703f12d87b6b5cf26039ecfa948dc5107a495418e5330")
+
+	var (
+		mu         sync.Mutex
+		broadcasts []uint64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/node_info":
+			_, _ = w.Write([]byte(`{"node_info":{"network":"bombay-12"}}`))
+		case "/auth/accounts/" + key.AccAddress().String():
+			bz, err := cdc.MarshalJSON(struct {
+				Height string             `json:"height"`
+				Result cosmosauth.Account `json:"result"`
+			}{Height: "100", Result: authtypes.NewBaseAccount(key.AccAddress(), nil, key.PubKey(), 7, 3)})
+			assert.NoError(t, err)
+			_, _ = w.Write(bz)
+		case "/txs":
+			bz, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			var req cosmosauthrest.BroadcastReq
+			assert.NoError(t, cdc.UnmarshalJSON(bz, &req))
+
+			for sequence := uint64(3); sequence < 6; sequence++ {
+				signMsg := terraauth.StdSignMsg{
+					ChainID:       "bombay-12",
+					AccountNumber: 7,
+					Sequence:      sequence,
+					Fee:           req.Tx.Fee,
+					Msgs:          req.Tx.Msgs,
+					Memo:          req.Tx.Memo,
+				}
+				if key.PubKey().VerifyBytes(signMsg.Bytes(), req.Tx.Signatures[0].Signature) {
+					broadcasts = append(broadcasts, sequence)
+				}
+			}
+			if len(broadcasts) == 1 {
+				// the tx may have reached the mempool before the gateway gave up
+				w.WriteHeader(http.StatusGatewayTimeout)
+				_, _ = w.Write([]byte(`{"error":"gateway timeout"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"height":"0","txhash":"ABCD","code":0}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	queue, err := NewBroadcastQueue(ctx, NewClient(httpclient.New(cdc, server.URL)), key, BroadcastQueueOptions{Size: 2})
+	assert.NoError(t, err)
+
+	fee := terraauth.StdFee{Gas: 200000}
+	results := make([]<-chan BroadcastResult, 2)
+	for index := range results {
+		results[index], err = queue.Enqueue(ctx, CreateTxOptions{
+			Msgs: []cosmostypes.Msg{terrabank.MsgSend{
+				FromAddress: key.AccAddress(),
+				ToAddress:   cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address()),
+				Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+			}},
+			Fee:  &fee,
+			Memo: fmt.Sprintf("payout #%d", index),
+		})
+		assert.NoError(t, err)
+	}
+	queue.Close()
+
+	first := <-results[0]
+	assert.Error(t, first.Err)
+	assert.Equal(t, uint64(3), first.Sequence)
+	second := <-results[1]
+	assert.NoError(t, second.Err)
+	assert.Equal(t, uint64(4), second.Sequence)
+	assert.Equal(t, []uint64{3, 4}, broadcasts)
+}
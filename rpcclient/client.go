@@ -0,0 +1,147 @@
+package rpcclient
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	tdmtrpc "github.com/tendermint/tendermint/rpc/core/types"
+	tdmttypes "github.com/tendermint/tendermint/types"
+)
+
+type MerkleProof = merkle.Proof
+
+//go:generate mockgen -destination ../../../test/mocks/terra/rpcclient/client.go . Client
+type Client interface {
+	ABCIQuery(ctx context.Context, path string, data []byte) ([]byte, int64, error)
+	ABCIQueryWithProof(ctx context.Context, path string, data []byte) ([]byte, MerkleProof, int64, error)
+	Tx(ctx context.Context, hash []byte) (tdmtrpc.ResultTx, error)
+	ConsensusParams(ctx context.Context) (tdmttypes.ConsensusParams, error)
+}
+
+type rpcClient struct {
+	client httpclient.Client
+}
+
+// New wraps a client pointed at the Tendermint RPC endpoint (usually :26657) rather than the LCD.
+func New(client httpclient.Client) Client {
+	return rpcClient{client: client}
+}
+
+// ABCIQuery is ABCIQueryWithProof without the proof, for paths that have none, e.g. /app/simulate.
+func (c rpcClient) ABCIQuery(ctx context.Context, path string, data []byte) ([]byte, int64, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/abci_query",
+		Query: map[string]string{
+			"path":  fmt.Sprintf("%q", path),
+			"data":  "0x" + hex.EncodeToString(data),
+			"prove": "false",
+		},
+	}
+
+	var body struct {
+		Result tdmtrpc.ResultABCIQuery `json:"result"`
+	}
+	if err := c.client.RequestJSON(payload, &body); err != nil {
+		return nil, 0, errors.Wrap(err, "request json")
+	}
+
+	resp := body.Result.Response
+	if !resp.IsOK() {
+		return nil, 0, errors.Errorf("abci query failed with code %d: %s", resp.Code, resp.Log)
+	}
+	return resp.Value, resp.Height, nil
+}
+
+func (c rpcClient) ABCIQueryWithProof(
+	ctx context.Context,
+	path string,
+	data []byte,
+) ([]byte, MerkleProof, int64, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/abci_query",
+		Query: map[string]string{
+			"path":  fmt.Sprintf("%q", path),
+			"data":  "0x" + hex.EncodeToString(data),
+			"prove": "true",
+		},
+	}
+
+	var body struct {
+		Result tdmtrpc.ResultABCIQuery `json:"result"`
+	}
+	if err := c.client.RequestJSON(payload, &body); err != nil {
+		return nil, MerkleProof{}, 0, errors.Wrap(err, "request json")
+	}
+
+	resp := body.Result.Response
+	if !resp.IsOK() {
+		return nil, MerkleProof{}, 0, errors.Errorf("abci query failed with code %d: %s", resp.Code, resp.Log)
+	}
+	if resp.Proof == nil {
+		return nil, MerkleProof{}, 0, ErrMissingProof
+	}
+	return resp.Value, *resp.Proof, resp.Height, nil
+}
+
+func (c rpcClient) Tx(ctx context.Context, hash []byte) (tdmtrpc.ResultTx, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/tx",
+		Query: map[string]string{
+			"hash":  "0x" + hex.EncodeToString(hash),
+			"prove": "false",
+		},
+	}
+
+	var body struct {
+		Result tdmtrpc.ResultTx `json:"result"`
+	}
+	if err := c.client.RequestJSON(payload, &body); err != nil {
+		return tdmtrpc.ResultTx{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// ConsensusParams returns the consensus params at the latest height. The legacy LCD has no route for them.
+func (c rpcClient) ConsensusParams(ctx context.Context) (tdmttypes.ConsensusParams, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/consensus_params",
+	}
+
+	var body struct {
+		Result tdmtrpc.ResultConsensusParams `json:"result"`
+	}
+	if err := c.client.RequestJSON(payload, &body); err != nil {
+		return tdmttypes.ConsensusParams{}, errors.Wrap(err, "request json")
+	}
+	return body.Result.ConsensusParams, nil
+}
+
+// VerifyProof checks the proof of key in the given store against appHash.
+// A nil value verifies the absence of the key. Note that the app hash of the state
+// at height H is committed in the header of block H+1.
+func VerifyProof(proof MerkleProof, appHash []byte, storeName string, key, value []byte) error {
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingURL)
+
+	runtime := rootmulti.DefaultProofRuntime()
+	if value == nil {
+		return runtime.VerifyAbsence(&proof, appHash, keyPath.String())
+	}
+	return runtime.VerifyValue(&proof, appHash, keyPath.String(), value)
+}
@@ -0,0 +1,65 @@
+package rpcclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/tj/assert"
+)
+
+func TestABCIQueryWithProof(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/abci_query", r.URL.Path)
+		assert.Equal(t, `"/store/acc/key"`, r.URL.Query().Get("path"))
+		assert.Equal(t, "0x01ff", r.URL.Query().Get("data"))
+		assert.Equal(t, "true", r.URL.Query().Get("prove"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"response":{
+			"code":0,"log":"","info":"","index":"0",
+			"key":"Af8=","value":"dmFsdWU=",
+			"proof":{"ops":[
+				{"type":"iavl:v","key":"Af8=","data":"aWF2bA=="},
+				{"type":"multistore","key":"YWNj","data":"bXVsdGk="}
+			]},
+			"height":"1234","codespace":""
+		}}}`))
+	}))
+	defer server.Close()
+
+	value, proof, height, err := New(httpclient.New(nil, server.URL)).
+		ABCIQueryWithProof(context.Background(), "/store/acc/key", []byte{0x01, 0xff})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	assert.Equal(t, int64(1234), height)
+	assert.Len(t, proof.Ops, 2)
+	assert.Equal(t, "iavl:v", proof.Ops[0].Type)
+	assert.Equal(t, []byte{0x01, 0xff}, proof.Ops[0].Key)
+	assert.Equal(t, "multistore", proof.Ops[1].Type)
+	assert.Equal(t, []byte("acc"), proof.Ops[1].Key)
+
+	assert.Error(t, VerifyProof(proof, []byte("app hash"), "acc", []byte{0x01, 0xff}, value))
+}
+
+func TestConsensusParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/consensus_params", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"block_height":"100","consensus_params":{
+			"block":{"max_bytes":"22020096","max_gas":"100000000","time_iota_ms":"1000"},
+			"evidence":{"max_age_num_blocks":"100000","max_age_duration":"172800000000000"},
+			"validator":{"pub_key_types":["ed25519"]}
+		}}}`))
+	}))
+	defer server.Close()
+
+	params, err := New(httpclient.New(nil, server.URL)).ConsensusParams(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100000000), params.Block.MaxGas)
+	assert.Equal(t, int64(22020096), params.Block.MaxBytes)
+}
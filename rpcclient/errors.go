@@ -0,0 +1,7 @@
+package rpcclient
+
+import "github.com/pkg/errors"
+
+var (
+	ErrMissingProof = errors.New("abci query response has no proof")
+)
@@ -0,0 +1,94 @@
+package terra
+
+import (
+	"fmt"
+	"strings"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terrabank "github.com/terra-project/core/x/bank"
+)
+
+// SendRecord is one payout row as loaded from e.g. a CSV file.
+type SendRecord struct {
+	Address string
+	Amount  string
+	Denom   string
+}
+
+type InvalidSendRecord struct {
+	// Row is the index of the record in the given records.
+	Row int
+	Err error
+}
+
+// InvalidSendRecordsError lists the records BuildSendsFromRecords left out.
+type InvalidSendRecordsError struct {
+	Records []InvalidSendRecord
+}
+
+func (e *InvalidSendRecordsError) Error() string {
+	rows := make([]string, len(e.Records))
+	for i, record := range e.Records {
+		rows[i] = fmt.Sprintf("row %d: %s", record.Row, record.Err)
+	}
+	return "invalid send records: " + strings.Join(rows, "; ")
+}
+
+// BuildSendsFromRecords returns a MsgSend from from per valid record, or a single MsgMultiSend
+// paying all of them if multiSend is set. Invalid records are skipped and reported with an
+// *InvalidSendRecordsError, returned along with the msgs of the valid ones.
+func BuildSendsFromRecords(
+	from cosmostypes.AccAddress,
+	records []SendRecord,
+	multiSend bool,
+) ([]cosmostypes.Msg, error) {
+	var (
+		outputs []terrabank.Output
+		invalid []InvalidSendRecord
+	)
+	for row, record := range records {
+		output, err := parseSendRecord(record)
+		if err != nil {
+			invalid = append(invalid, InvalidSendRecord{Row: row, Err: err})
+			continue
+		}
+		outputs = append(outputs, output)
+	}
+
+	var msgs []cosmostypes.Msg
+	if multiSend && len(outputs) > 0 {
+		var total cosmostypes.Coins
+		for _, output := range outputs {
+			total = total.Add(output.Coins...)
+		}
+		msgs = append(msgs, terrabank.NewMsgMultiSend([]terrabank.Input{terrabank.NewInput(from, total)}, outputs))
+	} else {
+		for _, output := range outputs {
+			msgs = append(msgs, terrabank.NewMsgSend(from, output.Address, output.Coins))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return msgs, &InvalidSendRecordsError{Records: invalid}
+	}
+	return msgs, nil
+}
+
+func parseSendRecord(record SendRecord) (terrabank.Output, error) {
+	addr, err := cosmostypes.AccAddressFromBech32(strings.TrimSpace(record.Address))
+	if err != nil {
+		return terrabank.Output{}, errors.Wrapf(err, "address %q", record.Address)
+	}
+
+	denom := strings.TrimSpace(record.Denom)
+	if err := cosmostypes.ValidateDenom(denom); err != nil {
+		return terrabank.Output{}, errors.Wrapf(err, "denom %q", record.Denom)
+	}
+
+	amount, ok := cosmostypes.NewIntFromString(strings.TrimSpace(record.Amount))
+	if !ok || !amount.IsPositive() {
+		return terrabank.Output{}, errors.Errorf("amount %q is not a positive integer", record.Amount)
+	}
+	return terrabank.NewOutput(addr, cosmostypes.NewCoins(cosmostypes.NewCoin(denom, amount))), nil
+}
@@ -0,0 +1,49 @@
+package terra
+
+import (
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestBuildSendsFromRecords(t *testing.T) {
+	from := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	alice := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	bob := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	records := []SendRecord{
+		{Address: alice.String(), Amount: "1000", Denom: "uluna"},
+		{Address: "terra1invalid", Amount: "1000", Denom: "uluna"},
+		{Address: bob.String(), Amount: "-5", Denom: "uusd"},
+		{Address: bob.String(), Amount: " 2500 ", Denom: "uusd"},
+		{Address: alice.String(), Amount: "10", Denom: "U"},
+	}
+
+	assertInvalidRows := func(err error) {
+		var invalid *InvalidSendRecordsError
+		assert.True(t, errors.As(err, &invalid))
+		assert.Len(t, invalid.Records, 3)
+		assert.Equal(t, 1, invalid.Records[0].Row)
+		assert.Equal(t, 2, invalid.Records[1].Row)
+		assert.Equal(t, 4, invalid.Records[2].Row)
+	}
+
+	msgs, err := BuildSendsFromRecords(from, records, false)
+	assertInvalidRows(err)
+	assert.Equal(t, []cosmostypes.Msg{
+		terrabank.NewMsgSend(from, alice, cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 1000))),
+		terrabank.NewMsgSend(from, bob, cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 2500))),
+	}, msgs)
+
+	msgs, err = BuildSendsFromRecords(from, records, true)
+	assertInvalidRows(err)
+	assert.Len(t, msgs, 1)
+	multiSend := msgs[0].(terrabank.MsgMultiSend)
+	assert.Equal(t, "1000uluna,2500uusd", multiSend.Inputs[0].Coins.String())
+	assert.Len(t, multiSend.Outputs, 2)
+	assert.NoError(t, multiSend.ValidateBasic())
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/bech32"
+)
+
+// prefixedClient carries the bech32 prefixes of the network to the services built on it.
+type prefixedClient struct {
+	httpclient.Client
+	prefix types.AddressPrefix
+}
+
+// WithAddressPrefix makes the services built on the returned client put addresses in request paths
+// and tx searches with prefix rather than the terra prefixes the global cosmos config is sealed with.
+// Addresses and pubkeys in RequestJSON responses are re-encoded with the terra prefixes so that they
+// decode; their String() is therefore terra, format them with prefix for display. Bodies returned by
+// Request are passed through untouched.
+func WithAddressPrefix(client httpclient.Client, prefix types.AddressPrefix) httpclient.Client {
+	return prefixedClient{Client: client, prefix: prefix}
+}
+
+// AddressPrefixOf returns the prefixes set on client with WithAddressPrefix, the terra ones if none.
+func AddressPrefixOf(client httpclient.Client) types.AddressPrefix {
+	if c, ok := client.(prefixedClient); ok {
+		return c.prefix
+	}
+	return types.TerraAddressPrefix
+}
+
+// accAddress formats addr for a request to client. Encoding only fails on an invalid prefix, which
+// then falls back to the terra address for the node to reject.
+func accAddress(client httpclient.Client, addr cosmostypes.AccAddress) string {
+	formatted, err := AddressPrefixOf(client).FormatAccAddress(addr)
+	if err != nil {
+		return addr.String()
+	}
+	return formatted
+}
+
+func valAddress(client httpclient.Client, addr cosmostypes.ValAddress) string {
+	formatted, err := AddressPrefixOf(client).FormatValAddress(addr)
+	if err != nil {
+		return addr.String()
+	}
+	return formatted
+}
+
+// RequestJSON re-encodes the bech32 strings carrying prefix in the response with the terra prefixes
+// before decoding, since the sealed global config makes the codec reject any other prefix.
+func (c prefixedClient) RequestJSON(payload httpclient.RequestPayload, respBody interface{}) error {
+	var rawBody json.RawMessage
+	if err := c.Client.RequestJSON(payload, &rawBody); err != nil {
+		return err
+	}
+	rawBody, err := toTerraPrefix(rawBody, c.prefix)
+	if err != nil {
+		return err
+	}
+	return httpclient.Decode(c.Codec(), payload.Path, rawBody, respBody)
+}
+
+func toTerraPrefix(rawBody []byte, prefix types.AddressPrefix) ([]byte, error) {
+	terra := types.TerraAddressPrefix
+	hrps := map[string]string{
+		prefix.Account:           terra.Account,
+		prefix.Account + "pub":   terra.Account + "pub",
+		prefix.Validator:         terra.Validator,
+		prefix.Validator + "pub": terra.Validator + "pub",
+		prefix.Consensus:         terra.Consensus,
+		prefix.Consensus + "pub": terra.Consensus + "pub",
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(rawBody))
+	decoder.UseNumber() // keep big ints and decs as they are
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, errors.Wrap(err, "parse response body for address prefix")
+	}
+	return json.Marshal(rewriteBech32(v, hrps))
+}
+
+func rewriteBech32(v interface{}, hrps map[string]string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = rewriteBech32(value, hrps)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = rewriteBech32(value, hrps)
+		}
+		return v
+	case string:
+		hrp, bz, err := bech32.DecodeAndConvert(v)
+		if err != nil {
+			return v
+		}
+		terraHrp, ok := hrps[hrp]
+		if !ok {
+			return v
+		}
+		converted, err := bech32.ConvertAndEncode(terraHrp, bz)
+		if err != nil {
+			return v
+		}
+		return converted
+	default:
+		return v
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosauth "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/tendermint/tendermint/libs/bech32"
+	terraapp "github.com/terra-project/core/app"
+	"github.com/tj/assert"
+)
+
+func TestWithAddressPrefixDecodesResponses(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	prefix := types.AddressPrefix{Account: "kava", Validator: "kavavaloper", Consensus: "kavavalcons"}
+
+	// the node answers with its own prefixes, so rewrite the terra encoded bodies the way it would
+	kava := func(body, address, hrp string) string {
+		_, bz, err := bech32.DecodeAndConvert(address)
+		assert.NoError(t, err)
+		kavaAddress, err := bech32.ConvertAndEncode(hrp, bz)
+		assert.NoError(t, err)
+		return strings.Replace(body, address, kavaAddress, -1)
+	}
+
+	addr := mockAddress(1)
+	coins := cosmostypes.NewCoins(cosmostypes.NewInt64Coin("ukava", 1000))
+	accountJSON, err := cdc.MarshalJSON(struct {
+		Height string             `json:"height"`
+		Result cosmosauth.Account `json:"result"`
+	}{Height: "100", Result: authtypes.NewBaseAccount(addr, coins, nil, 7, 3)})
+	assert.NoError(t, err)
+	kavaAddr, err := prefix.FormatAccAddress(addr)
+	assert.NoError(t, err)
+
+	operator := cosmostypes.ValAddress(mockAddress(2))
+	validator := mockValidator(operator, 2, false, "20000", "20000.000000000000000000")
+	kavaOperator, err := prefix.FormatValAddress(operator)
+	assert.NoError(t, err)
+	var decoded struct {
+		ConsensusPubKey string `json:"consensus_pubkey"`
+	}
+	assert.NoError(t, cdc.UnmarshalJSON([]byte(validator), &decoded))
+	validator = kava(validator, operator.String(), prefix.Validator)
+	validator = kava(validator, decoded.ConsensusPubKey, prefix.Consensus+"pub")
+	assert.False(t, strings.Contains(validator, "terra"))
+
+	client, closer := newMockClient(map[string]string{
+		"/auth/accounts/" + kavaAddr:          kava(string(accountJSON), addr.String(), prefix.Account),
+		"/staking/validators/" + kavaOperator: `{"height":"100","result":` + validator + `}`,
+	})
+	defer closer()
+	client = WithAddressPrefix(client, prefix)
+	ctx := context.Background()
+
+	account, err := NewAuthService(client).GetAccountInfo(ctx, addr)
+	assert.NoError(t, err)
+	assert.Equal(t, addr, account.GetAddress())
+	assert.Equal(t, uint64(7), account.GetAccountNumber())
+	assert.Equal(t, uint64(3), account.GetSequence())
+	assert.Equal(t, "1000ukava", account.GetCoins().String())
+
+	val, err := NewStakingService(client).GetValidator(ctx, operator)
+	assert.NoError(t, err)
+	assert.Equal(t, operator, val.OperatorAddress)
+	assert.Equal(t, decoded.ConsensusPubKey, cosmostypes.MustBech32ifyPubKey(cosmostypes.Bech32PubKeyTypeConsPub, val.ConsPubKey))
+	assert.Equal(t, "20000", val.Tokens.String())
+}
@@ -16,6 +16,7 @@ import (
 //go:generate mockgen -destination ../../../test/mocks/terra/service/service_auth.go . AuthService
 type AuthService interface {
 	GetAccountInfo(ctx context.Context, addr cosmostypes.AccAddress) (cosmosauth.Account, error)
+	Exists(ctx context.Context, addr cosmostypes.AccAddress) (bool, error)
 }
 
 type authService struct {
@@ -34,7 +35,7 @@ func (svc authService) GetAccountInfo(
 	var payload = httpclient.RequestPayload{
 		Context: ctx,
 		Method:  http.MethodGet,
-		Path:    fmt.Sprintf("/auth/accounts/%s", addr.String()),
+		Path:    fmt.Sprintf("/auth/accounts/%s", accAddress(svc.client, addr)),
 	}
 
 	var body struct {
@@ -46,3 +47,19 @@ func (svc authService) GetAccountInfo(
 	}
 	return body.Result, nil
 }
+
+// Exists reports whether addr has an account record on chain. The record is created on an
+// address's first incoming transfer, but on some versions an address can hold a balance from
+// genesis or a module without one, so false means "never seen as an account", not "empty".
+// Only transport and server failures are returned as errors.
+func (svc authService) Exists(ctx context.Context, addr cosmostypes.AccAddress) (bool, error) {
+	account, err := svc.GetAccountInfo(ctx, addr)
+	if err != nil {
+		if httpclient.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "fetch account %s", addr.String())
+	}
+	// the legacy LCD answers an unknown address with an empty BaseAccount rather than a 404
+	return account != nil && !account.GetAddress().Empty(), nil
+}
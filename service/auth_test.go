@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosauth "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	terraapp "github.com/terra-project/core/app"
+	"github.com/tj/assert"
+)
+
+func TestAccountExists(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+
+	existing := mockAddress(1)
+	empty := mockAddress(2)
+	missing := mockAddress(3)
+	broken := mockAddress(4)
+
+	accountJSON := func(account cosmosauth.Account) string {
+		bz, err := cdc.MarshalJSON(struct {
+			Height string             `json:"height"`
+			Result cosmosauth.Account `json:"result"`
+		}{Height: "100", Result: account})
+		assert.NoError(t, err)
+		return string(bz)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/accounts/" + existing.String():
+			coins := cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 1000))
+			_, _ = w.Write([]byte(accountJSON(authtypes.NewBaseAccount(existing, coins, nil, 1, 0))))
+		case "/auth/accounts/" + empty.String():
+			_, _ = w.Write([]byte(accountJSON(&authtypes.BaseAccount{})))
+		case "/auth/accounts/" + broken.String():
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"internal error"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewAuthService(httpclient.New(cdc, server.URL))
+	ctx := context.Background()
+
+	exists, err := svc.Exists(ctx, existing)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = svc.Exists(ctx, empty)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = svc.Exists(ctx, missing)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = svc.Exists(ctx, broken)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, httpclient.StatusCode(err))
+}
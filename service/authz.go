@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terramsgauth "github.com/terra-project/core/x/msgauth"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_authz.go . AuthzService
+type AuthzService interface {
+	GetGrants(
+		ctx context.Context,
+		granter, grantee cosmostypes.AccAddress,
+	) ([]terramsgauth.AuthorizationGrant, error)
+}
+
+type authzService struct {
+	codec  *codec.Codec
+	client httpclient.Client
+}
+
+func NewAuthzService(client httpclient.Client) AuthzService {
+	return authzService{codec: client.Codec(), client: client}
+}
+
+// GetGrants lists the grants given by granter to grantee.
+// On this chain version authorizations are served by Terra's msgauth module, the legacy LCD's
+// counterpart of x/authz. Fee allowances have no such counterpart, see FeegrantService.
+func (svc authzService) GetGrants(
+	ctx context.Context,
+	granter, grantee cosmostypes.AccAddress,
+) ([]terramsgauth.AuthorizationGrant, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path: fmt.Sprintf(
+			"/msgauth/granters/%s/grantees/%s/grants",
+			accAddress(svc.client, granter), accAddress(svc.client, grantee),
+		),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint                  `json:"height"`
+		Result []terramsgauth.AuthorizationGrant `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		if httpclient.IsNotFound(err) {
+			return []terramsgauth.AuthorizationGrant{}, nil
+		}
+		return nil, errors.Wrap(err, "request json")
+	}
+	if body.Result == nil {
+		return []terramsgauth.AuthorizationGrant{}, nil
+	}
+	return body.Result, nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	terramsgauth "github.com/terra-project/core/x/msgauth"
+	"github.com/tj/assert"
+)
+
+func TestGetGrants(t *testing.T) {
+	ctx := context.Background()
+
+	granter, grantee := mockAddress(1), mockAddress(2)
+	client, closer := newMockClient(map[string]string{
+		"/msgauth/granters/" + granter.String() + "/grantees/" + grantee.String() + "/grants": `{"height":"100","result":[{
+			"authorization":{"type":"msgauth/SendAuthorization","value":{"spend_limit":[{"denom":"uusd","amount":"1000000"}]}},
+			"expiration":"2022-01-01T00:00:00Z"
+		}]}`,
+	})
+	defer closer()
+	svc := NewAuthzService(client)
+
+	grants, err := svc.GetGrants(ctx, granter, grantee)
+	assert.NoError(t, err)
+	assert.Len(t, grants, 1)
+	assert.Equal(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), grants[0].Expiration.UTC())
+
+	sendAuth, ok := grants[0].Authorization.(terramsgauth.SendAuthorization)
+	assert.True(t, ok)
+	assert.Equal(t, "1000000uusd", sendAuth.SpendLimit.String())
+
+	grants, err = svc.GetGrants(ctx, grantee, granter)
+	assert.NoError(t, err)
+	assert.Empty(t, grants)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/cawabunga/terra.go/httpclient"
 
@@ -15,22 +17,36 @@ import (
 //go:generate mockgen -destination ../../../test/mocks/terra/service/service_bank.go . BankService
 type BankService interface {
 	GetBalance(ctx context.Context, acc cosmostypes.AccAddress) (GetBalanceResponse, error)
+	GetSpendableBalances(ctx context.Context, acc cosmostypes.AccAddress) (cosmostypes.Coins, error)
+	GetTotalSupply(ctx context.Context, denom string) (cosmostypes.Int, error)
+	GetTotalSupplies(ctx context.Context) (cosmostypes.Coins, error)
+	GetSupplyChange(ctx context.Context, from, to int64) (map[string]cosmostypes.Int, error)
+	GetDenomOwners(ctx context.Context, denom string) ([]DenomOwner, error)
 }
 
+const denomOwnersPageLimit = 100
+
 type bankService struct {
-	codec  *codec.Codec
-	client httpclient.Client
+	codec      *codec.Codec
+	client     httpclient.Client
+	auth       AuthService
+	tendermint TendermintService
 }
 
 func NewBankService(client httpclient.Client) BankService {
-	return bankService{codec: client.Codec(), client: client}
+	return bankService{
+		codec:      client.Codec(),
+		client:     client,
+		auth:       NewAuthService(client),
+		tendermint: NewTendermintService(client),
+	}
 }
 
 func (svc bankService) GetBalance(ctx context.Context, acc cosmostypes.AccAddress) (GetBalanceResponse, error) {
 	var payload = httpclient.RequestPayload{
 		Context: ctx,
 		Method:  http.MethodGet,
-		Path:    fmt.Sprintf("/bank/balances/%s", acc.String()),
+		Path:    fmt.Sprintf("/bank/balances/%s", accAddress(svc.client, acc)),
 	}
 
 	var body struct {
@@ -45,3 +61,142 @@ func (svc bankService) GetBalance(ctx context.Context, acc cosmostypes.AccAddres
 		Balance: body.Result,
 	}, nil
 }
+
+// GetSpendableBalances returns the balance excluding tokens still locked by a vesting schedule.
+// On LCDs without the v1beta1 endpoint, it's computed from the account at the latest block time.
+func (svc bankService) GetSpendableBalances(
+	ctx context.Context,
+	acc cosmostypes.AccAddress,
+) (cosmostypes.Coins, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/cosmos/bank/v1beta1/spendable_balances/%s", accAddress(svc.client, acc)),
+	}
+
+	var body struct {
+		Balances cosmostypes.Coins `json:"balances"`
+	}
+	err := svc.client.RequestJSON(payload, &body)
+	if err == nil {
+		return body.Balances, nil
+	}
+	if !httpclient.IsStatus(err, http.StatusNotFound, http.StatusNotImplemented) {
+		return nil, errors.Wrap(err, "request json")
+	}
+
+	account, err := svc.auth.GetAccountInfo(ctx, acc)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch account info")
+	}
+	_, block, err := svc.tendermint.GetBlockByHeight(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch latest block")
+	}
+	return account.SpendableCoins(block.Time), nil
+}
+
+func (svc bankService) GetTotalSupply(ctx context.Context, denom string) (cosmostypes.Int, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/supply/total/%s", denom),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint `json:"height"`
+		Result cosmostypes.Int  `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return cosmostypes.Int{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+func (svc bankService) GetTotalSupplies(ctx context.Context) (cosmostypes.Coins, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/supply/total",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint  `json:"height"`
+		Result cosmostypes.Coins `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetSupplyChange returns how much the total supply of each denom changed from height from
+// to height to, negative for burned supply. It fails with ErrHeightPruned if the node no longer
+// has the state at either height, an archive node is needed then.
+func (svc bankService) GetSupplyChange(ctx context.Context, from, to int64) (map[string]cosmostypes.Int, error) {
+	before, err := svc.supplyAt(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := svc.supplyAt(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	change := make(map[string]cosmostypes.Int, len(after))
+	for _, coin := range after {
+		change[coin.Denom] = coin.Amount.Sub(before.AmountOf(coin.Denom))
+	}
+	for _, coin := range before {
+		if _, ok := change[coin.Denom]; !ok {
+			change[coin.Denom] = coin.Amount.Neg()
+		}
+	}
+	return change, nil
+}
+
+func (svc bankService) supplyAt(ctx context.Context, height int64) (cosmostypes.Coins, error) {
+	supply, err := svc.GetTotalSupplies(httpclient.AtHeight(ctx, height))
+	if err != nil {
+		if strings.Contains(err.Error(), "version does not exist") {
+			return nil, errors.Wrapf(ErrHeightPruned, "%d", height)
+		}
+		return nil, errors.Wrapf(err, "fetch total supply at %d", height)
+	}
+	return supply, nil
+}
+
+// GetDenomOwners returns every address holding denom with its balance. The grpc-gateway serves them a
+// page of denomOwnersPageLimit at a time, followed here through next_key until the last page. A denom
+// like uluna has enough holders for this to take thousands of requests and a lot of memory.
+func (svc bankService) GetDenomOwners(ctx context.Context, denom string) ([]DenomOwner, error) {
+	var (
+		owners  []DenomOwner
+		nextKey string
+	)
+	for {
+		var payload = httpclient.RequestPayload{
+			Context: ctx,
+			Method:  http.MethodGet,
+			Path:    fmt.Sprintf("/cosmos/bank/v1beta1/denom_owners/%s", denom),
+			Query:   map[string]string{"pagination.limit": strconv.Itoa(denomOwnersPageLimit)},
+		}
+		if nextKey != "" {
+			payload.Query["pagination.key"] = nextKey
+		}
+
+		var body struct {
+			DenomOwners []DenomOwner `json:"denom_owners"`
+			Pagination  Pagination   `json:"pagination"`
+		}
+		if err := svc.client.RequestJSON(payload, &body); err != nil {
+			return nil, errors.Wrap(err, "request json")
+		}
+		owners = append(owners, body.DenomOwners...)
+
+		if body.Pagination.NextKey == "" {
+			return owners, nil
+		}
+		nextKey = body.Pagination.NextKey
+	}
+}
@@ -6,3 +6,8 @@ type GetBalanceResponse struct {
 	Height  uint64            `json:"height"`
 	Balance cosmostypes.Coins `json:"balance"`
 }
+
+type DenomOwner struct {
+	Address cosmostypes.AccAddress `json:"address"`
+	Balance cosmostypes.Coin       `json:"balance"`
+}
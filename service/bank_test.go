@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosauth "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/pkg/errors"
+	terraapp "github.com/terra-project/core/app"
+	"github.com/tj/assert"
+)
+
+func TestGetSpendableBalancesFromVestingAccount(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+
+	addr := mockAddress(1)
+	total := cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 1000))
+	blockTime := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	account := &authvesting.DelayedVestingAccount{
+		BaseVestingAccount: &authvesting.BaseVestingAccount{
+			BaseAccount:     authtypes.NewBaseAccount(addr, total, nil, 1, 0),
+			OriginalVesting: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 600)),
+			EndTime:         blockTime.Add(24 * time.Hour).Unix(),
+		},
+	}
+	accountJSON, err := cdc.MarshalJSON(struct {
+		Height string             `json:"height"`
+		Result cosmosauth.Account `json:"result"`
+	}{Height: "100", Result: account})
+	assert.NoError(t, err)
+
+	client, closer := newMockClient(map[string]string{
+		"/auth/accounts/" + addr.String(): string(accountJSON),
+		"/blocks/latest":                  `{"block_id":{},"block":{"header":{"height":"100","time":"2021-06-01T00:00:00Z"}}}`,
+	})
+	defer closer()
+
+	spendable, err := NewBankService(client).GetSpendableBalances(context.Background(), addr)
+	assert.NoError(t, err)
+	assert.Equal(t, "400uluna", spendable.String())
+	assert.Equal(t, "1000uluna", account.GetCoins().String())
+}
+
+func TestGetSupplyChange(t *testing.T) {
+	supplies := map[string]string{
+		"100": `[{"denom":"uluna","amount":"1000"},{"denom":"ukrw","amount":"300"},{"denom":"umnt","amount":"50"}]`,
+		"200": `[{"denom":"uluna","amount":"900"},{"denom":"ukrw","amount":"450"},{"denom":"uusd","amount":"20"}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/supply/total", r.URL.Path)
+		height := r.Header.Get(httpclient.HeightHeader)
+
+		supply, ok := supplies[height]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"failed to load state at height ` + height + `; version does not exist"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"` + height + `","result":` + supply + `}`))
+	}))
+	defer server.Close()
+
+	svc := NewBankService(httpclient.New(nil, server.URL))
+
+	change, err := svc.GetSupplyChange(context.Background(), 100, 200)
+	assert.NoError(t, err)
+	assert.Len(t, change, 4)
+	assert.Equal(t, int64(-100), change["uluna"].Int64())
+	assert.Equal(t, int64(150), change["ukrw"].Int64())
+	assert.Equal(t, int64(-50), change["umnt"].Int64())
+	assert.Equal(t, int64(20), change["uusd"].Int64())
+
+	_, err = svc.GetSupplyChange(context.Background(), 1, 200)
+	assert.True(t, errors.Is(err, ErrHeightPruned))
+}
+
+func TestGetDenomOwners(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/cosmos/bank/v1beta1/denom_owners/uusd", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("pagination.limit"))
+		key := r.URL.Query().Get("pagination.key")
+		keys = append(keys, key)
+
+		w.Header().Set("Content-Type", "application/json")
+		if key == "" {
+			_, _ = w.Write([]byte(`{"denom_owners":[
+				{"address":"` + mockAddress(1).String() + `","balance":{"denom":"uusd","amount":"1000"}},
+				{"address":"` + mockAddress(2).String() + `","balance":{"denom":"uusd","amount":"2000"}}
+			],"pagination":{"next_key":"bmV4dA==","total":"3"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"denom_owners":[
+			{"address":"` + mockAddress(3).String() + `","balance":{"denom":"uusd","amount":"3000"}}
+		],"pagination":{"next_key":null,"total":"3"}}`))
+	}))
+	defer server.Close()
+
+	owners, err := NewBankService(httpclient.New(nil, server.URL)).GetDenomOwners(context.Background(), "uusd")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "bmV4dA=="}, keys)
+	assert.Equal(t, []DenomOwner{
+		{Address: mockAddress(1), Balance: cosmostypes.NewInt64Coin("uusd", 1000)},
+		{Address: mockAddress(2), Balance: cosmostypes.NewInt64Coin("uusd", 2000)},
+		{Address: mockAddress(3), Balance: cosmostypes.NewInt64Coin("uusd", 3000)},
+	}, owners)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	cosmostypes "github.com/cosmos/cosmos-sdk/types"
@@ -18,16 +20,35 @@ import (
 type ContractService interface {
 	GetCodeID(ctx context.Context, codeId uint64) (terrawasm.CodeInfo, error)
 	GetContractInfo(ctx context.Context, addr cosmostypes.AccAddress) (terrawasm.ContractInfo, error)
+	IsContract(ctx context.Context, addr cosmostypes.AccAddress) (bool, error)
 	QueryContractStore(ctx context.Context, addr cosmostypes.AccAddress, query interface{}, resp interface{}) error
+	QueryContractTyped(ctx context.Context, addr cosmostypes.AccAddress, query interface{}, result interface{}) error
+	GetContractsByCode(ctx context.Context, codeId uint64) ([]cosmostypes.AccAddress, error)
+	GetContractHistory(ctx context.Context, addr cosmostypes.AccAddress) ([]ContractCodeHistoryEntry, error)
+	GetContractTxs(ctx context.Context, addr cosmostypes.AccAddress, page, limit int64) (QueryTxResponse, error)
+}
+
+const contractsPageLimit = 100
+
+// contractAddressEventKeys are the events a contract execution is indexed by, from the oldest chain version.
+var contractAddressEventKeys = []string{
+	"execute_contract.contract_address",
+	"wasm.contract_address",
+	"wasm._contract_address",
 }
 
 type contractService struct {
-	codec  *codec.Codec
-	client httpclient.Client
+	codec       *codec.Codec
+	client      httpclient.Client
+	transaction TransactionService
 }
 
 func NewContractService(client httpclient.Client) ContractService {
-	return contractService{codec: client.Codec(), client: client}
+	return contractService{
+		codec:       client.Codec(),
+		client:      client,
+		transaction: NewTransactionService(client),
+	}
 }
 
 func (svc contractService) GetCodeID(ctx context.Context, codeId uint64) (terrawasm.CodeInfo, error) {
@@ -54,7 +75,7 @@ func (svc contractService) GetContractInfo(
 	var payload = httpclient.RequestPayload{
 		Context: ctx,
 		Method:  http.MethodGet,
-		Path:    fmt.Sprintf("/wasm/contracts/%s", addr.String()),
+		Path:    fmt.Sprintf("/wasm/contracts/%s", accAddress(svc.client, addr)),
 	}
 
 	var body struct {
@@ -67,6 +88,17 @@ func (svc contractService) GetContractInfo(
 	return body.Result, nil
 }
 
+func (svc contractService) IsContract(ctx context.Context, addr cosmostypes.AccAddress) (bool, error) {
+	info, err := svc.GetContractInfo(ctx, addr)
+	if err != nil {
+		if httpclient.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "fetch contract info")
+	}
+	return !info.Address.Empty(), nil
+}
+
 func (svc contractService) QueryContractStore(
 	ctx context.Context,
 	addr cosmostypes.AccAddress,
@@ -81,7 +113,7 @@ func (svc contractService) QueryContractStore(
 	var payload = httpclient.RequestPayload{
 		Context: ctx,
 		Method:  http.MethodGet,
-		Path:    fmt.Sprintf("/wasm/contracts/%s/store", addr.String()),
+		Path:    fmt.Sprintf("/wasm/contracts/%s/store", accAddress(svc.client, addr)),
 		Query:   map[string]string{"query_msg": string(jsonQuery)},
 	}
 
@@ -90,3 +122,144 @@ func (svc contractService) QueryContractStore(
 	}
 	return nil
 }
+
+// QueryContractTyped is QueryContractStore that decodes only the contract's answer into result,
+// so callers pass a pointer to the response type rather than one wrapped in height and result.
+func (svc contractService) QueryContractTyped(
+	ctx context.Context,
+	addr cosmostypes.AccAddress,
+	query interface{},
+	result interface{},
+) error {
+	var body struct {
+		Height string          `json:"height"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := svc.QueryContractStore(ctx, addr, query, &body); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body.Result, result); err != nil {
+		return errors.Wrap(err, "unmarshal query result")
+	}
+	return nil
+}
+
+// GetContractsByCode returns the addresses of every contract instantiated from codeId, typed
+// the way the other ContractService methods take them.
+// Legacy LCD versions that ignore page and limit return the whole list on every page,
+// so paging stops at the first page that starts with the address of the page before.
+func (svc contractService) GetContractsByCode(ctx context.Context, codeId uint64) ([]cosmostypes.AccAddress, error) {
+	var contracts []cosmostypes.AccAddress
+	var first cosmostypes.AccAddress
+	for page := 1; ; page++ {
+		var payload = httpclient.RequestPayload{
+			Context: ctx,
+			Method:  http.MethodGet,
+			Path:    fmt.Sprintf("/wasm/codes/%d/contracts", codeId),
+			Query: map[string]string{
+				"page":  strconv.Itoa(page),
+				"limit": strconv.Itoa(contractsPageLimit),
+			},
+		}
+
+		var body struct {
+			Height string                   `json:"height"`
+			Result []cosmostypes.AccAddress `json:"result"`
+		}
+		if err := svc.client.RequestJSON(payload, &body); err != nil {
+			return nil, errors.Wrapf(err, "request json of page %d", page)
+		}
+		if len(body.Result) < contractsPageLimit {
+			return append(contracts, body.Result...), nil
+		}
+		if body.Result[0].Equals(first) {
+			return contracts, nil
+		}
+		first = body.Result[0]
+		contracts = append(contracts, body.Result...)
+	}
+}
+
+// GetContractHistory returns the code ids addr has run, oldest first, starting from its instantiation.
+func (svc contractService) GetContractHistory(
+	ctx context.Context,
+	addr cosmostypes.AccAddress,
+) ([]ContractCodeHistoryEntry, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/wasm/contracts/%s/history", accAddress(svc.client, addr)),
+	}
+
+	var body struct {
+		Height string                     `json:"height"`
+		Result []ContractCodeHistoryEntry `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetContractTxs returns a page of the txs executing the contract at addr, latest first: page 1
+// holds the newest txs. The chain indexes them by one of contractAddressEventKeys depending on its
+// version, and tendermint fails past page 1 for a key with no matches, so the key is picked with a
+// one-tx probe before paging. Pages count back from the last page of the oldest-first search, so
+// page 1 may hold fewer than limit txs.
+func (svc contractService) GetContractTxs(
+	ctx context.Context,
+	addr cosmostypes.AccAddress,
+	page, limit int64,
+) (QueryTxResponse, error) {
+	if page < 1 || limit < 1 {
+		return QueryTxResponse{}, errors.Errorf("invalid page %d or limit %d", page, limit)
+	}
+	address := accAddress(svc.client, addr)
+
+	var (
+		key   string
+		total int64
+	)
+	for _, k := range contractAddressEventKeys {
+		probePage, probeLimit := int64(1), int64(1)
+		probe, err := svc.transaction.QueryTx(ctx, QueryTxRequest{
+			Page:  &probePage,
+			Limit: &probeLimit,
+			Query: types.Q{k: address},
+		})
+		if err != nil {
+			return QueryTxResponse{}, errors.Wrapf(err, "probe txs by %s", k)
+		}
+		if !probe.TotalCount.IsNil() && probe.TotalCount.IsPositive() {
+			key, total = k, probe.TotalCount.Int64()
+			break
+		}
+	}
+
+	pageTotal := (total + limit - 1) / limit
+	if key == "" || page > pageTotal {
+		return QueryTxResponse{
+			TotalCount: cosmostypes.NewInt(total),
+			Count:      cosmostypes.ZeroInt(),
+			PageNumber: cosmostypes.NewInt(page),
+			PageTotal:  cosmostypes.NewInt(pageTotal),
+			Limit:      cosmostypes.NewInt(limit),
+			Txs:        []cosmostypes.TxResponse{},
+		}, nil
+	}
+
+	searchPage := pageTotal - page + 1
+	resp, err := svc.transaction.QueryTx(ctx, QueryTxRequest{
+		Page:  &searchPage,
+		Limit: &limit,
+		Query: types.Q{key: address},
+	})
+	if err != nil {
+		return QueryTxResponse{}, errors.Wrapf(err, "search txs by %s", key)
+	}
+	for i, j := 0, len(resp.Txs)-1; i < j; i, j = i+1, j-1 {
+		resp.Txs[i], resp.Txs[j] = resp.Txs[j], resp.Txs[i]
+	}
+	resp.PageNumber = cosmostypes.NewInt(page)
+	return resp, nil
+}
@@ -0,0 +1,18 @@
+package service
+
+import "encoding/json"
+
+type ContractCodeHistoryOperation string
+
+const (
+	ContractOperationInit    ContractCodeHistoryOperation = "Init"
+	ContractOperationMigrate ContractCodeHistoryOperation = "Migrate"
+	ContractOperationGenesis ContractCodeHistoryOperation = "Genesis"
+)
+
+type ContractCodeHistoryEntry struct {
+	Operation ContractCodeHistoryOperation `json:"operation"`
+	CodeID    uint64                       `json:"code_id"`
+	// Msg is the init or migrate msg as sent, left raw since its schema is contract-specific.
+	Msg json.RawMessage `json:"msg"`
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tj/assert"
+)
+
+func TestIsContract(t *testing.T) {
+	ctx := context.Background()
+
+	contract, err := cosmostypes.AccAddressFromBech32("terra15dwd5mj8v59wpj0wvt233mf5efdff808c5tkal")
+	assert.NoError(t, err)
+	account, err := cosmostypes.AccAddressFromBech32("terra12avq876h9mn3wehchcezaafd4kdyjzer4u79kc")
+	assert.NoError(t, err)
+
+	client, closer := newMockClient(map[string]string{
+		"/wasm/contracts/" + contract.String(): `{"height":"100","result":{
+			"address":"` + contract.String() + `","owner":"` + account.String() + `","code_id":1,"migratable":false
+		}}`,
+	})
+	svc := NewContractService(client)
+
+	isContract, err := svc.IsContract(ctx, contract)
+	assert.NoError(t, err)
+	assert.True(t, isContract)
+
+	isContract, err = svc.IsContract(ctx, account)
+	assert.NoError(t, err)
+	assert.False(t, isContract)
+
+	closer()
+	_, err = svc.IsContract(ctx, contract)
+	assert.Error(t, err)
+}
+
+func TestQueryContractTyped(t *testing.T) {
+	contract := mockAddress(1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wasm/contracts/"+contract.String()+"/store", r.URL.Path)
+		assert.JSONEq(t, `{"balance":{"address":"terra1"}}`, r.URL.Query().Get("query_msg"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"100","result":{"balance":"1000"}}`))
+	}))
+	defer server.Close()
+
+	var balance struct {
+		Balance cosmostypes.Int `json:"balance"`
+	}
+	err := NewContractService(httpclient.New(nil, server.URL)).QueryContractTyped(
+		context.Background(),
+		contract,
+		map[string]interface{}{"balance": map[string]string{"address": "terra1"}},
+		&balance,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", balance.Balance.String())
+}
+
+func TestGetContractsByCode(t *testing.T) {
+	var contracts []string
+	for i := 0; i < contractsPageLimit+2; i++ {
+		contracts = append(contracts, mockAddress(byte(i)).String())
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wasm/codes/3/contracts", r.URL.Path)
+
+		page := contracts[:contractsPageLimit]
+		if r.URL.Query().Get("page") == "2" {
+			page = contracts[contractsPageLimit:]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"100","result":["` + strings.Join(page, `","`) + `"]}`))
+	}))
+	defer server.Close()
+
+	result, err := NewContractService(httpclient.New(nil, server.URL)).GetContractsByCode(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Len(t, result, contractsPageLimit+2)
+	for i, addr := range result {
+		assert.Equal(t, contracts[i], addr.String())
+	}
+}
+
+func TestGetContractsByCodeIgnoredPaging(t *testing.T) {
+	var contracts []string
+	for i := 0; i < contractsPageLimit; i++ {
+		contracts = append(contracts, mockAddress(byte(i)).String())
+	}
+
+	// old LCDs answer every page with the same list
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"100","result":["` + strings.Join(contracts, `","`) + `"]}`))
+	}))
+	defer server.Close()
+
+	result, err := NewContractService(httpclient.New(nil, server.URL)).GetContractsByCode(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Len(t, result, contractsPageLimit)
+	assert.Equal(t, 2, requests)
+}
+
+func TestGetContractHistory(t *testing.T) {
+	contract := mockAddress(1)
+
+	client, closer := newMockClient(map[string]string{
+		"/wasm/contracts/" + contract.String() + "/history": `{"height":"100","result":[
+			{"operation":"Init","code_id":3,"msg":{"name":"token","decimals":6}},
+			{"operation":"Migrate","code_id":7,"msg":{}}
+		]}`,
+	})
+	defer closer()
+
+	history, err := NewContractService(client).GetContractHistory(context.Background(), contract)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	assert.Equal(t, ContractOperationInit, history[0].Operation)
+	assert.Equal(t, uint64(3), history[0].CodeID)
+	assert.JSONEq(t, `{"name":"token","decimals":6}`, string(history[0].Msg))
+
+	assert.Equal(t, ContractOperationMigrate, history[1].Operation)
+	assert.Equal(t, uint64(7), history[1].CodeID)
+	assert.JSONEq(t, `{}`, string(history[1].Msg))
+}
+
+func TestGetContractTxs(t *testing.T) {
+	contract := mockAddress(1)
+
+	// only the newer event key indexes the contract, with 5 txs at heights 100 to 104
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		total := 0
+		for _, key := range contractAddressEventKeys {
+			if query.Get(key) == contract.String() {
+				keys = append(keys, key)
+				if key == "wasm.contract_address" {
+					total = 5
+				}
+			}
+		}
+		pageTotal := (total + limit - 1) / limit
+		if page > 1 && page > pageTotal {
+			// what tendermint does, even for a key without matches
+			http.Error(w, `{"error":"page should be within [1, 1] range"}`, http.StatusInternalServerError)
+			return
+		}
+
+		var txs []string
+		for i := (page - 1) * limit; i < page*limit && i < total; i++ {
+			txs = append(txs, fmt.Sprintf(`{"height":"%d","txhash":"TX%d","code":0,"raw_log":"[]"}`, 100+i, 100+i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w,
+			`{"total_count":"%d","count":"%d","page_number":"%d","page_total":"%d","limit":"%d","txs":[%s]}`,
+			total, len(txs), page, pageTotal, limit, strings.Join(txs, ","),
+		)
+	}))
+	defer server.Close()
+	svc := NewContractService(httpclient.New(nil, server.URL))
+
+	resp, err := svc.GetContractTxs(context.Background(), contract, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"execute_contract.contract_address", "wasm.contract_address", "wasm.contract_address"}, keys)
+	assert.Len(t, resp.Txs, 1)
+	assert.Equal(t, "TX104", resp.Txs[0].TxHash)
+	assert.Equal(t, int64(5), resp.TotalCount.Int64())
+	assert.Equal(t, int64(1), resp.PageNumber.Int64())
+
+	resp, err = svc.GetContractTxs(context.Background(), contract, 2, 2)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Txs, 2)
+	assert.Equal(t, "TX103", resp.Txs[0].TxHash)
+	assert.Equal(t, "TX102", resp.Txs[1].TxHash)
+	assert.Equal(t, int64(2), resp.PageNumber.Int64())
+
+	resp, err = svc.GetContractTxs(context.Background(), contract, 4, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Txs)
+	assert.Equal(t, int64(3), resp.PageTotal.Int64())
+}
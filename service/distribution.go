@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosdistr "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/pkg/errors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_distribution.go . DistributionService
+type DistributionService interface {
+	GetDelegatorRewards(
+		ctx context.Context,
+		delegator cosmostypes.AccAddress,
+	) (cosmosdistr.QueryDelegatorTotalRewardsResponse, error)
+	GetTotalRewards(ctx context.Context, delegator cosmostypes.AccAddress) (cosmostypes.DecCoins, error)
+	GetDelegationRewards(
+		ctx context.Context,
+		delegator cosmostypes.AccAddress,
+		validator cosmostypes.ValAddress,
+	) (cosmostypes.DecCoins, error)
+	GetValidatorSlashes(
+		ctx context.Context,
+		validator cosmostypes.ValAddress,
+		startHeight, endHeight int64,
+	) ([]SlashEvent, error)
+	GetParams(ctx context.Context) (cosmosdistr.Params, error)
+	GetCommunityTax(ctx context.Context) (cosmostypes.Dec, error)
+	GetRewardHistory(ctx context.Context, delegator cosmostypes.AccAddress) ([]RewardWithdrawal, error)
+}
+
+type distributionService struct {
+	codec       *codec.Codec
+	client      httpclient.Client
+	transaction TransactionService
+}
+
+func NewDistributionService(client httpclient.Client) DistributionService {
+	return distributionService{
+		codec:       client.Codec(),
+		client:      client,
+		transaction: NewTransactionService(client),
+	}
+}
+
+func (svc distributionService) GetDelegatorRewards(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) (cosmosdistr.QueryDelegatorTotalRewardsResponse, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/distribution/delegators/%s/rewards", accAddress(svc.client, delegator)),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint                               `json:"height"`
+		Result cosmosdistr.QueryDelegatorTotalRewardsResponse `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return cosmosdistr.QueryDelegatorTotalRewardsResponse{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+func (svc distributionService) GetTotalRewards(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) (cosmostypes.DecCoins, error) {
+	rewards, err := svc.GetDelegatorRewards(ctx, delegator)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch rewards of %s", delegator.String())
+	}
+	return rewards.Total, nil
+}
+
+// GetDelegationRewards returns the rewards delegator has accrued at validator so far.
+func (svc distributionService) GetDelegationRewards(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+	validator cosmostypes.ValAddress,
+) (cosmostypes.DecCoins, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path: fmt.Sprintf(
+			"/distribution/delegators/%s/rewards/%s",
+			accAddress(svc.client, delegator), valAddress(svc.client, validator),
+		),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint     `json:"height"`
+		Result cosmostypes.DecCoins `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+	if body.Result == nil {
+		return cosmostypes.DecCoins{}, nil
+	}
+	return body.Result, nil
+}
+
+// GetValidatorSlashes returns the slashes of validator between startHeight and endHeight, both inclusive,
+// in the order they happened. Each one cut the stake delegated to validator at the time by its fraction.
+func (svc distributionService) GetValidatorSlashes(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+	startHeight, endHeight int64,
+) ([]SlashEvent, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/distribution/validators/%s/slashes", valAddress(svc.client, validator)),
+		Query: map[string]string{
+			"starting_height": strconv.FormatInt(startHeight, 10),
+			"ending_height":   strconv.FormatInt(endHeight, 10),
+		},
+	}
+
+	var body struct {
+		Height cosmostypes.Uint `json:"height"`
+		Result []SlashEvent     `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+func (svc distributionService) GetParams(ctx context.Context) (cosmosdistr.Params, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/distribution/parameters",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint   `json:"height"`
+		Result cosmosdistr.Params `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return cosmosdistr.Params{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+func (svc distributionService) GetCommunityTax(ctx context.Context) (cosmostypes.Dec, error) {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch distribution params")
+	}
+	return params.CommunityTax, nil
+}
+
+// GetRewardHistory returns the rewards delegator withdrew, one entry per successful withdrawal tx
+// ordered by height. The amounts come from the withdraw_rewards events of each msg, as the msgs only
+// name the validator. A tx that also delegates, e.g. claim-and-restake, has the delegated coins in
+// Restaked.
+func (svc distributionService) GetRewardHistory(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) ([]RewardWithdrawal, error) {
+	history := make([]RewardWithdrawal, 0)
+	for page := int64(1); ; page++ {
+		p, limit := page, int64(txSearchPageLimit)
+		resp, err := svc.transaction.QueryTx(ctx, QueryTxRequest{
+			Page:  &p,
+			Limit: &limit,
+			Query: types.Q{
+				"message.action": "withdraw_delegator_reward",
+				"message.sender": accAddress(svc.client, delegator),
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "search withdraw_delegator_reward txs of page %d", page)
+		}
+
+		for _, txResp := range resp.Txs {
+			if txResp.Code != abcitypes.CodeTypeOK {
+				continue
+			}
+			withdrawal, err := rewardWithdrawal(txResp, delegator)
+			if err != nil {
+				return nil, err
+			}
+			history = append(history, withdrawal)
+		}
+
+		if resp.PageTotal.IsNil() || resp.PageTotal.Int64() <= page {
+			break
+		}
+	}
+
+	sort.SliceStable(history, func(i, j int) bool { return history[i].Height < history[j].Height })
+	return history, nil
+}
+
+func rewardWithdrawal(txResp cosmostypes.TxResponse, delegator cosmostypes.AccAddress) (RewardWithdrawal, error) {
+	timestamp, err := types.ParseBlockTime(txResp.Timestamp)
+	if err != nil {
+		return RewardWithdrawal{}, errors.Wrapf(err, "parse timestamp of tx %s", txResp.TxHash)
+	}
+	withdrawal := RewardWithdrawal{
+		Height:      txResp.Height,
+		TxHash:      txResp.TxHash,
+		Timestamp:   timestamp,
+		Amount:      cosmostypes.NewCoins(),
+		ByValidator: map[string]cosmostypes.Coins{},
+		Restaked:    cosmostypes.NewCoins(),
+	}
+
+	for _, log := range txResp.Logs {
+		for _, event := range log.Events {
+			if event.Type != cosmosdistr.EventTypeWithdrawRewards {
+				continue
+			}
+
+			var amount cosmostypes.Coins
+			var validator string
+			for _, attr := range event.Attributes {
+				switch attr.Key {
+				case cosmostypes.AttributeKeyAmount:
+					coins, err := cosmostypes.ParseCoins(attr.Value)
+					if err != nil {
+						return RewardWithdrawal{}, errors.Wrapf(err, "parse withdrawn amount of tx %s", txResp.TxHash)
+					}
+					amount = coins
+				case cosmosdistr.AttributeKeyValidator:
+					validator = attr.Value
+				}
+			}
+			withdrawal.Amount = withdrawal.Amount.Add(amount...)
+			withdrawal.ByValidator[validator] = withdrawal.ByValidator[validator].Add(amount...)
+		}
+	}
+
+	tx, err := DecodedTx(txResp)
+	if err != nil {
+		return RewardWithdrawal{}, errors.Wrapf(err, "decode tx %s", txResp.TxHash)
+	}
+	for _, msg := range tx.Msgs {
+		if delegate, ok := msg.(stakingtypes.MsgDelegate); ok && delegate.DelegatorAddress.Equals(delegator) {
+			withdrawal.Restaked = withdrawal.Restaked.Add(delegate.Amount)
+		}
+	}
+	return withdrawal, nil
+}
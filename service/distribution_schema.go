@@ -0,0 +1,24 @@
+package service
+
+import (
+	"time"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+type SlashEvent struct {
+	Height          int64           `json:"height"`
+	ValidatorPeriod uint64          `json:"validator_period"`
+	Fraction        cosmostypes.Dec `json:"fraction"`
+}
+
+type RewardWithdrawal struct {
+	Height    int64     `json:"height"`
+	TxHash    string    `json:"txhash"`
+	Timestamp time.Time `json:"timestamp"`
+	// Amount is the total withdrawn in the tx, ByValidator the same by validator operator address.
+	Amount      cosmostypes.Coins            `json:"amount"`
+	ByValidator map[string]cosmostypes.Coins `json:"by_validator"`
+	// Restaked is what the tx delegated again right away.
+	Restaked cosmostypes.Coins `json:"restaked"`
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosdistr "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	terraapp "github.com/terra-project/core/app"
+	terraauth "github.com/terra-project/core/x/auth"
+	"github.com/tj/assert"
+)
+
+func TestDistributionTotalRewards(t *testing.T) {
+	ctx := context.Background()
+
+	delegator, err := cosmostypes.AccAddressFromBech32("terra12avq876h9mn3wehchcezaafd4kdyjzer4u79kc")
+	assert.NoError(t, err)
+	validator := cosmostypes.ValAddress(delegator)
+
+	client, closer := newMockClient(map[string]string{
+		"/distribution/delegators/" + delegator.String() + "/rewards": `{"height":"100","result":{
+			"rewards":[{"validator_address":"` + validator.String() + `","reward":[{"denom":"uluna","amount":"10.500000000000000000"}]}],
+			"total":[{"denom":"uluna","amount":"10.500000000000000000"},{"denom":"uusd","amount":"3.000000000000000000"}]
+		}}`,
+	})
+	defer closer()
+
+	total, err := NewDistributionService(client).GetTotalRewards(ctx, delegator)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.500000000000000000uluna,3.000000000000000000uusd", total.String())
+}
+
+func TestDistributionDelegationRewards(t *testing.T) {
+	ctx := context.Background()
+
+	delegator := mockAddress(1)
+	rewarding, idle := cosmostypes.ValAddress(mockAddress(2)), cosmostypes.ValAddress(mockAddress(3))
+
+	prefix := "/distribution/delegators/" + delegator.String() + "/rewards/"
+	client, closer := newMockClient(map[string]string{
+		prefix + rewarding.String(): `{"height":"100","result":[{"denom":"uluna","amount":"10.500000000000000000"}]}`,
+		prefix + idle.String():      `{"height":"100","result":null}`,
+	})
+	defer closer()
+
+	svc := NewDistributionService(client)
+
+	rewards, err := svc.GetDelegationRewards(ctx, delegator, rewarding)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.500000000000000000uluna", rewards.String())
+
+	rewards, err = svc.GetDelegationRewards(ctx, delegator, idle)
+	assert.NoError(t, err)
+	assert.NotNil(t, rewards)
+	assert.True(t, rewards.IsZero())
+}
+
+func TestGetValidatorSlashes(t *testing.T) {
+	validator := cosmostypes.ValAddress(mockAddress(1))
+
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/distribution/validators/"+validator.String()+"/slashes", r.URL.Path)
+		query = r.URL.Query()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"5000","result":[
+			{"height":"1200","validator_period":"14","fraction":"0.000100000000000000"},
+			{"height":"3400","validator_period":"31","fraction":"0.010000000000000000"}
+		]}`))
+	}))
+	defer server.Close()
+
+	slashes, err := NewDistributionService(httpclient.New(nil, server.URL)).
+		GetValidatorSlashes(context.Background(), validator, 1000, 5000)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", query.Get("starting_height"))
+	assert.Equal(t, "5000", query.Get("ending_height"))
+	assert.Equal(t, []SlashEvent{
+		{Height: 1200, ValidatorPeriod: 14, Fraction: cosmostypes.NewDecWithPrec(1, 4)},
+		{Height: 3400, ValidatorPeriod: 31, Fraction: cosmostypes.NewDecWithPrec(1, 2)},
+	}, slashes)
+}
+
+func TestDistributionCommunityTax(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/distribution/parameters": `{"height":"100","result":{
+			"community_tax":"0.020000000000000000",
+			"base_proposer_reward":"0.010000000000000000",
+			"bonus_proposer_reward":"0.040000000000000000",
+			"withdraw_addr_enabled":true
+		}}`,
+	})
+	defer closer()
+
+	tax, err := NewDistributionService(client).GetCommunityTax(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, cosmostypes.NewDecWithPrec(2, 2), tax)
+}
+
+func TestGetRewardHistory(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	delegator := mockAddress(1)
+	validatorA := cosmostypes.ValAddress(mockAddress(2))
+	validatorB := cosmostypes.ValAddress(mockAddress(3))
+
+	withdrawLog := func(index uint16, validator cosmostypes.ValAddress, amount string) cosmostypes.ABCIMessageLog {
+		return cosmostypes.ABCIMessageLog{MsgIndex: index, Events: cosmostypes.StringEvents{{
+			Type: "withdraw_rewards",
+			Attributes: []cosmostypes.Attribute{
+				{Key: "amount", Value: amount},
+				{Key: "validator", Value: validator.String()},
+			},
+		}}}
+	}
+	withdrawMsg := func(validator cosmostypes.ValAddress) cosmostypes.Msg {
+		return cosmosdistr.NewMsgWithdrawDelegatorReward(delegator, validator)
+	}
+	fee := terraauth.StdFee{Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 3000)), Gas: 200000}
+
+	resp, err := cdc.MarshalJSON(QueryTxResponse{
+		TotalCount: cosmostypes.NewInt(2),
+		Count:      cosmostypes.NewInt(2),
+		PageNumber: cosmostypes.NewInt(1),
+		PageTotal:  cosmostypes.NewInt(1),
+		Limit:      cosmostypes.NewInt(100),
+		Txs: []cosmostypes.TxResponse{
+			{
+				// claim from both validators and restake on A
+				Height:    200,
+				TxHash:    "RESTAKE",
+				Timestamp: "2021-10-02T00:00:00Z",
+				Logs: cosmostypes.ABCIMessageLogs{
+					withdrawLog(0, validatorA, "3000uluna,50uusd"),
+					withdrawLog(1, validatorB, "2000uluna"),
+					{MsgIndex: 2},
+				},
+				Tx: terraauth.NewStdTx([]cosmostypes.Msg{
+					withdrawMsg(validatorA),
+					withdrawMsg(validatorB),
+					stakingtypes.NewMsgDelegate(delegator, validatorA, cosmostypes.NewInt64Coin("uluna", 5000)),
+				}, fee, nil, ""),
+			},
+			{
+				Height:    100,
+				TxHash:    "CLAIM",
+				Timestamp: "2021-10-01T00:00:00.123Z",
+				Logs:      cosmostypes.ABCIMessageLogs{withdrawLog(0, validatorA, "1000uluna")},
+				Tx:        terraauth.NewStdTx([]cosmostypes.Msg{withdrawMsg(validatorA)}, fee, nil, ""),
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(resp)
+	}))
+	defer server.Close()
+
+	history, err := NewDistributionService(httpclient.New(cdc, server.URL)).GetRewardHistory(context.Background(), delegator)
+	assert.NoError(t, err)
+	assert.Equal(t, "withdraw_delegator_reward", query.Get("message.action"))
+	assert.Equal(t, delegator.String(), query.Get("message.sender"))
+	assert.Len(t, history, 2)
+
+	assert.Equal(t, "CLAIM", history[0].TxHash)
+	assert.Equal(t, int64(100), history[0].Height)
+	assert.Equal(t, time.Date(2021, 10, 1, 0, 0, 0, 123000000, time.UTC), history[0].Timestamp)
+	assert.Equal(t, "1000uluna", history[0].Amount.String())
+	assert.Empty(t, history[0].Restaked)
+
+	assert.Equal(t, "RESTAKE", history[1].TxHash)
+	assert.Equal(t, "5000uluna,50uusd", history[1].Amount.String())
+	assert.Equal(t, "3000uluna,50uusd", history[1].ByValidator[validatorA.String()].String())
+	assert.Equal(t, "2000uluna", history[1].ByValidator[validatorB.String()].String())
+	assert.Equal(t, "5000uluna", history[1].Restaked.String())
+}
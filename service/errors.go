@@ -0,0 +1,20 @@
+package service
+
+import "github.com/pkg/errors"
+
+var (
+	ErrAllowanceNotFound   = errors.New("fee allowance not found")
+	ErrUnexpectedTxType    = errors.New("tx is not a StdTx")
+	ErrWaitTimedOut        = errors.New("tx not found by the wait's timeout height")
+	ErrSigningInfoNotFound = errors.New("validator signing info not found")
+	ErrNoCompletedEpoch    = errors.New("no treasury epoch has completed yet")
+	ErrStaleSequence       = errors.New("tx is signed with a sequence that was already used")
+	ErrWrongAccountNumber  = errors.New("tx signature doesn't match the signer's account number")
+	ErrNoFeeMarket         = errors.New("chain has no fee market module")
+	ErrMaxEntriesExceeded  = errors.New("too many unbonding or redelegation entries in progress")
+	ErrHeightPruned        = errors.New("node has pruned the state at height")
+	ErrGasPriceTooLow      = errors.New("fee is below the minimum gas price")
+	ErrBlockBasedMinting   = errors.New("chain mints every block rather than per epoch")
+
+	ErrAggregatePrevoteNotFound = errors.New("aggregate prevote not found")
+)
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_feegrant.go . FeegrantService
+type FeegrantService interface {
+	GetAllowance(ctx context.Context, granter, grantee cosmostypes.AccAddress) (FeeGrant, error)
+	GetAllowances(ctx context.Context, grantee cosmostypes.AccAddress) ([]FeeGrant, error)
+	GetSponsoredFees(ctx context.Context, granter cosmostypes.AccAddress) (SponsoredFees, error)
+}
+
+// feegrantService reads x/feegrant over its grpc-gateway routes. Unlike authorizations, which the
+// legacy LCD serves through Terra's msgauth module, fee allowances have no legacy module or route,
+// so they're only answered by nodes that have x/feegrant and serve /cosmos/ routes. Elsewhere
+// GetAllowance fails with ErrAllowanceNotFound and GetAllowances with the 404 or 501 of the node.
+type feegrantService struct {
+	codec       *codec.Codec
+	client      httpclient.Client
+	transaction TransactionService
+}
+
+func NewFeegrantService(client httpclient.Client) FeegrantService {
+	return feegrantService{
+		codec:       client.Codec(),
+		client:      client,
+		transaction: NewTransactionService(client),
+	}
+}
+
+func (svc feegrantService) GetAllowance(
+	ctx context.Context,
+	granter, grantee cosmostypes.AccAddress,
+) (FeeGrant, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path: fmt.Sprintf(
+			"/cosmos/feegrant/v1beta1/allowance/%s/%s",
+			accAddress(svc.client, granter), accAddress(svc.client, grantee),
+		),
+	}
+
+	var body struct {
+		Allowance FeeGrant `json:"allowance"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		if httpclient.IsNotFound(err) {
+			return FeeGrant{}, errors.Wrapf(ErrAllowanceNotFound, "granter=%s grantee=%s", granter.String(), grantee.String())
+		}
+		return FeeGrant{}, errors.Wrap(err, "request json")
+	}
+	return body.Allowance, nil
+}
+
+func (svc feegrantService) GetAllowances(ctx context.Context, grantee cosmostypes.AccAddress) ([]FeeGrant, error) {
+	var (
+		grants  []FeeGrant
+		nextKey string
+	)
+	for {
+		var payload = httpclient.RequestPayload{
+			Context: ctx,
+			Method:  http.MethodGet,
+			Path:    fmt.Sprintf("/cosmos/feegrant/v1beta1/allowances/%s", accAddress(svc.client, grantee)),
+			Query:   map[string]string{},
+		}
+		if nextKey != "" {
+			payload.Query["pagination.key"] = nextKey
+		}
+
+		var body struct {
+			Allowances []FeeGrant `json:"allowances"`
+			Pagination Pagination `json:"pagination"`
+		}
+		if err := svc.client.RequestJSON(payload, &body); err != nil {
+			return nil, errors.Wrap(err, "request json")
+		}
+		grants = append(grants, body.Allowances...)
+
+		if body.Pagination.NextKey == "" {
+			return grants, nil
+		}
+		nextKey = body.Pagination.NextKey
+	}
+}
+
+// GetSponsoredFees sums the fees granter paid for other accounts through its allowances. The LCD has no
+// index of fees by payer, so this walks every tx carrying the use_feegrant event of granter and adds up
+// their fees, charged to the granter whether or not the tx succeeded. The grantee is the fee payer, the
+// first signer of the tx.
+func (svc feegrantService) GetSponsoredFees(
+	ctx context.Context,
+	granter cosmostypes.AccAddress,
+) (SponsoredFees, error) {
+	sponsored := SponsoredFees{ByGrantee: map[string]cosmostypes.Coins{}}
+	for page := int64(1); ; page++ {
+		p, limit := page, int64(txSearchPageLimit)
+		resp, err := svc.transaction.QueryTx(ctx, QueryTxRequest{
+			Page:  &p,
+			Limit: &limit,
+			Query: types.Q{"use_feegrant.granter": accAddress(svc.client, granter)},
+		})
+		if err != nil {
+			return SponsoredFees{}, errors.Wrapf(err, "search use_feegrant txs of page %d", page)
+		}
+
+		for _, txResp := range resp.Txs {
+			tx, err := DecodedTx(txResp)
+			if err != nil {
+				return SponsoredFees{}, errors.Wrapf(err, "decode tx %s", txResp.TxHash)
+			}
+			sponsored.Total = sponsored.Total.Add(tx.Fee.Amount...)
+			sponsored.TxCount++
+			if signers := tx.GetSigners(); len(signers) > 0 {
+				grantee := signers[0].String()
+				sponsored.ByGrantee[grantee] = sponsored.ByGrantee[grantee].Add(tx.Fee.Amount...)
+			}
+		}
+
+		if resp.PageTotal.IsNil() || resp.PageTotal.Int64() <= page {
+			break
+		}
+	}
+	return sponsored, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+type FeeGrant struct {
+	Granter   cosmostypes.AccAddress `json:"granter"`
+	Grantee   cosmostypes.AccAddress `json:"grantee"`
+	Allowance FeeAllowance           `json:"allowance"`
+}
+
+// FeeAllowance covers the fields shared by the feegrant allowance types.
+// Raw keeps the original message for allowance types with additional fields.
+type FeeAllowance struct {
+	Type       string            `json:"@type"`
+	SpendLimit cosmostypes.Coins `json:"spend_limit"`
+	Expiration *time.Time        `json:"expiration"`
+	Basic      *FeeAllowance     `json:"basic,omitempty"`
+	Raw        json.RawMessage   `json:"-"`
+}
+
+func (a *FeeAllowance) UnmarshalJSON(b []byte) error {
+	type alias FeeAllowance
+	var decoded alias
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+	*a = FeeAllowance(decoded)
+	a.Raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+type SponsoredFees struct {
+	Total     cosmostypes.Coins            `json:"total"`
+	TxCount   int                          `json:"tx_count"`
+	ByGrantee map[string]cosmostypes.Coins `json:"by_grantee"`
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraapp "github.com/terra-project/core/app"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestGetAllowance(t *testing.T) {
+	ctx := context.Background()
+
+	granter, grantee := mockAddress(1), mockAddress(2)
+	client, closer := newMockClient(map[string]string{
+		"/cosmos/feegrant/v1beta1/allowance/" + granter.String() + "/" + grantee.String(): `{"allowance":{
+			"granter":"` + granter.String() + `",
+			"grantee":"` + grantee.String() + `",
+			"allowance":{
+				"@type":"/cosmos.feegrant.v1beta1.BasicAllowance",
+				"spend_limit":[{"denom":"uluna","amount":"5000000"}],
+				"expiration":"2022-03-01T12:00:00Z"
+			}
+		}}`,
+	})
+	defer closer()
+	svc := NewFeegrantService(client)
+
+	grant, err := svc.GetAllowance(ctx, granter, grantee)
+	assert.NoError(t, err)
+	assert.Equal(t, granter, grant.Granter)
+	assert.Equal(t, "/cosmos.feegrant.v1beta1.BasicAllowance", grant.Allowance.Type)
+	assert.Equal(t, "5000000uluna", grant.Allowance.SpendLimit.String())
+	assert.Equal(t, time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC), grant.Allowance.Expiration.UTC())
+
+	_, err = svc.GetAllowance(ctx, grantee, granter)
+	assert.True(t, errors.Is(err, ErrAllowanceNotFound))
+}
+
+func TestGetAllowances(t *testing.T) {
+	grantee := mockAddress(2)
+
+	allowance := func(granter cosmostypes.AccAddress, limit string) string {
+		return `{"granter":"` + granter.String() + `","grantee":"` + grantee.String() + `","allowance":{
+			"@type":"/cosmos.feegrant.v1beta1.BasicAllowance",
+			"spend_limit":[{"denom":"uluna","amount":"` + limit + `"}]
+		}}`
+	}
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/cosmos/feegrant/v1beta1/allowances/"+grantee.String(), r.URL.Path)
+		key := r.URL.Query().Get("pagination.key")
+		keys = append(keys, key)
+
+		w.Header().Set("Content-Type", "application/json")
+		if key == "" {
+			_, _ = w.Write([]byte(`{"allowances":[` + allowance(mockAddress(1), "100") + `],
+				"pagination":{"next_key":"AQI=","total":"2"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"allowances":[` + allowance(mockAddress(3), "200") + `],
+			"pagination":{"next_key":null,"total":"2"}}`))
+	}))
+	defer server.Close()
+
+	grants, err := NewFeegrantService(httpclient.New(nil, server.URL)).GetAllowances(context.Background(), grantee)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "AQI="}, keys)
+	assert.Len(t, grants, 2)
+	assert.Equal(t, mockAddress(1), grants[0].Granter)
+	assert.Equal(t, "100uluna", grants[0].Allowance.SpendLimit.String())
+	assert.Equal(t, mockAddress(3), grants[1].Granter)
+	assert.Equal(t, "200uluna", grants[1].Allowance.SpendLimit.String())
+}
+
+func TestGetSponsoredFees(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	granter := mockAddress(1)
+
+	sponsoredTx := func(grantee cosmostypes.AccAddress, fee int64) terraauth.StdTx {
+		return terraauth.NewStdTx(
+			[]cosmostypes.Msg{terrabank.MsgSend{
+				FromAddress: grantee,
+				ToAddress:   mockAddress(9),
+				Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+			}},
+			terraauth.StdFee{Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", fee)), Gas: 200000},
+			nil,
+			"",
+		)
+	}
+	resp, err := cdc.MarshalJSON(QueryTxResponse{
+		TotalCount: cosmostypes.NewInt(3),
+		Count:      cosmostypes.NewInt(3),
+		PageNumber: cosmostypes.NewInt(1),
+		PageTotal:  cosmostypes.NewInt(1),
+		Limit:      cosmostypes.NewInt(100),
+		Txs: []cosmostypes.TxResponse{
+			{Height: 100, TxHash: "A", Tx: sponsoredTx(mockAddress(2), 3000)},
+			{Height: 101, TxHash: "B", Tx: sponsoredTx(mockAddress(3), 4500)},
+			{Height: 102, TxHash: "C", Code: 11, Tx: sponsoredTx(mockAddress(2), 1500)},
+		},
+	})
+	assert.NoError(t, err)
+
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(resp)
+	}))
+	defer server.Close()
+
+	sponsored, err := NewFeegrantService(httpclient.New(cdc, server.URL)).GetSponsoredFees(context.Background(), granter)
+	assert.NoError(t, err)
+	assert.Equal(t, granter.String(), query.Get("use_feegrant.granter"))
+	assert.Equal(t, "9000uusd", sponsored.Total.String())
+	assert.Equal(t, 3, sponsored.TxCount)
+	assert.Equal(t, "4500uusd", sponsored.ByGrantee[mockAddress(2).String()].String())
+	assert.Equal(t, "4500uusd", sponsored.ByGrantee[mockAddress(3).String()].String())
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_feemarket.go . FeeMarketService
+type FeeMarketService interface {
+	GetBaseFee(ctx context.Context) (cosmostypes.Dec, error)
+}
+
+type feeMarketService struct {
+	codec  *codec.Codec
+	client httpclient.Client
+}
+
+func NewFeeMarketService(client httpclient.Client) FeeMarketService {
+	return feeMarketService{codec: client.Codec(), client: client}
+}
+
+// GetBaseFee returns the gas price the feemarket module currently charges at least, in its fee denom.
+// It fails with ErrNoFeeMarket on chains without the module, where a static gas price applies.
+func (svc feeMarketService) GetBaseFee(ctx context.Context) (cosmostypes.Dec, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/feemarket/v1/state",
+	}
+
+	var body struct {
+		State struct {
+			BaseGasPrice cosmostypes.Dec `json:"base_gas_price"`
+		} `json:"state"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		if httpclient.IsNotFound(err) || httpclient.StatusCode(err) == http.StatusNotImplemented {
+			return cosmostypes.Dec{}, ErrNoFeeMarket
+		}
+		return cosmostypes.Dec{}, errors.Wrap(err, "request json")
+	}
+	return body.State.BaseGasPrice, nil
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tj/assert"
+)
+
+func TestGetBaseFee(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/feemarket/v1/state": `{"state":{
+			"base_gas_price":"0.015250000000000000",
+			"learning_rate":"0.125000000000000000",
+			"window":["120000","80000"],
+			"index":"1"
+		}}`,
+	})
+	defer closer()
+
+	fee, err := NewFeeMarketService(client).GetBaseFee(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, cosmostypes.NewDecWithPrec(1525, 5), fee)
+
+	absent, closer := newMockClient(nil)
+	defer closer()
+
+	_, err = NewFeeMarketService(absent).GetBaseFee(context.Background())
+	assert.Equal(t, ErrNoFeeMarket, err)
+}
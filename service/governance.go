@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_governance.go . GovernanceService
+type GovernanceService interface {
+	GetProposal(ctx context.Context, proposalID uint64) (govtypes.Proposal, error)
+	GetProposalPhase(ctx context.Context, proposalID uint64) (ProposalPhase, time.Duration, error)
+	GetVotes(ctx context.Context, proposalID uint64) (govtypes.Votes, error)
+	GetVotesWithValidatorMapping(ctx context.Context, proposalID uint64) ([]ValidatorVote, error)
+}
+
+const votesPageLimit = 100
+
+type governanceService struct {
+	codec      *codec.Codec
+	client     httpclient.Client
+	staking    StakingService
+	tendermint TendermintService
+}
+
+func NewGovernanceService(client httpclient.Client) GovernanceService {
+	return governanceService{
+		codec:      client.Codec(),
+		client:     client,
+		staking:    NewStakingService(client),
+		tendermint: NewTendermintService(client),
+	}
+}
+
+func (svc governanceService) GetProposal(ctx context.Context, proposalID uint64) (govtypes.Proposal, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/gov/proposals/%d", proposalID),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint  `json:"height"`
+		Result govtypes.Proposal `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return govtypes.Proposal{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetVotes returns every vote cast on the proposal. Legacy LCD versions that ignore page
+// and limit return all votes on every page, so paging stops at the first page that starts
+// with the voter of the page before.
+func (svc governanceService) GetVotes(ctx context.Context, proposalID uint64) (govtypes.Votes, error) {
+	var votes govtypes.Votes
+	var first cosmostypes.AccAddress
+	for page := 1; ; page++ {
+		var payload = httpclient.RequestPayload{
+			Context: ctx,
+			Method:  http.MethodGet,
+			Path:    fmt.Sprintf("/gov/proposals/%d/votes", proposalID),
+			Query: map[string]string{
+				"page":  strconv.Itoa(page),
+				"limit": strconv.Itoa(votesPageLimit),
+			},
+		}
+
+		var body struct {
+			Height cosmostypes.Uint `json:"height"`
+			Result govtypes.Votes   `json:"result"`
+		}
+		if err := svc.client.RequestJSON(payload, &body); err != nil {
+			return nil, errors.Wrap(err, "request json")
+		}
+		if len(body.Result) < votesPageLimit {
+			return append(votes, body.Result...), nil
+		}
+		if body.Result[0].Voter.Equals(first) {
+			return votes, nil
+		}
+		first = body.Result[0].Voter
+		votes = append(votes, body.Result...)
+	}
+}
+
+// GetVotesWithValidatorMapping returns the votes validators cast on the proposal from their
+// operator accounts, in the order of the votes. Votes of other accounts are left out.
+func (svc governanceService) GetVotesWithValidatorMapping(
+	ctx context.Context,
+	proposalID uint64,
+) ([]ValidatorVote, error) {
+	votes, err := svc.GetVotes(ctx, proposalID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch votes of proposal %d", proposalID)
+	}
+
+	validators, err := svc.staking.GetAllValidators(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch validators")
+	}
+	byAccount := make(map[string]stakingtypes.Validator, len(validators))
+	for _, validator := range validators {
+		byAccount[cosmostypes.AccAddress(validator.OperatorAddress).String()] = validator
+	}
+
+	var validatorVotes []ValidatorVote
+	for _, vote := range votes {
+		validator, ok := byAccount[vote.Voter.String()]
+		if !ok {
+			continue
+		}
+		validatorVotes = append(validatorVotes, ValidatorVote{
+			Validator: validator.OperatorAddress,
+			Moniker:   validator.Description.Moniker,
+			Option:    vote.Option,
+		})
+	}
+	return validatorVotes, nil
+}
+
+// GetProposalPhase returns the phase of the proposal and the time left in it,
+// measured against the latest block time rather than the local clock.
+func (svc governanceService) GetProposalPhase(
+	ctx context.Context,
+	proposalID uint64,
+) (ProposalPhase, time.Duration, error) {
+	proposal, err := svc.GetProposal(ctx, proposalID)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "fetch proposal %d", proposalID)
+	}
+
+	_, block, err := svc.tendermint.GetBlockByHeight(ctx, nil)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "fetch latest block")
+	}
+	return proposalPhase(proposal, block.Time)
+}
+
+func proposalPhase(proposal govtypes.Proposal, now time.Time) (ProposalPhase, time.Duration, error) {
+	switch proposal.Status {
+	case govtypes.StatusDepositPeriod:
+		return ProposalPhaseDeposit, remaining(proposal.DepositEndTime, now), nil
+	case govtypes.StatusVotingPeriod:
+		return ProposalPhaseVoting, remaining(proposal.VotingEndTime, now), nil
+	case govtypes.StatusPassed, govtypes.StatusRejected, govtypes.StatusFailed:
+		return ProposalPhaseEnded, 0, nil
+	default:
+		return "", 0, errors.Errorf("unknown proposal status %s", proposal.Status)
+	}
+}
+
+func remaining(end, now time.Time) time.Duration {
+	if left := end.Sub(now); left > 0 {
+		return left
+	}
+	return 0
+}
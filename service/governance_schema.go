@@ -0,0 +1,20 @@
+package service
+
+import (
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+type ProposalPhase string
+
+const (
+	ProposalPhaseDeposit ProposalPhase = "deposit"
+	ProposalPhaseVoting  ProposalPhase = "voting"
+	ProposalPhaseEnded   ProposalPhase = "ended"
+)
+
+type ValidatorVote struct {
+	Validator cosmostypes.ValAddress
+	Moniker   string
+	Option    govtypes.VoteOption
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	terraapp "github.com/terra-project/core/app"
+	"github.com/tj/assert"
+)
+
+func TestProposalPhase(t *testing.T) {
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	phase, left, err := proposalPhase(govtypes.Proposal{
+		Status:        govtypes.StatusVotingPeriod,
+		VotingEndTime: now.Add(36 * time.Hour),
+	}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalPhaseVoting, phase)
+	assert.Equal(t, 36*time.Hour, left)
+
+	phase, left, err = proposalPhase(govtypes.Proposal{
+		Status:         govtypes.StatusDepositPeriod,
+		DepositEndTime: now.Add(-time.Minute),
+	}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalPhaseDeposit, phase)
+	assert.Equal(t, time.Duration(0), left)
+
+	phase, _, err = proposalPhase(govtypes.Proposal{Status: govtypes.StatusPassed}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalPhaseEnded, phase)
+}
+
+func TestGetProposalPhase(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	blockTime := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	proposal := govtypes.NewProposal(
+		govtypes.NewTextProposal("title", "description"), 7, blockTime.Add(-72*time.Hour), blockTime.Add(-24*time.Hour),
+	)
+	proposal.Status = govtypes.StatusVotingPeriod
+	proposal.VotingStartTime = blockTime.Add(-24 * time.Hour)
+	proposal.VotingEndTime = blockTime.Add(12 * time.Hour)
+	proposalJSON, err := cdc.MarshalJSON(struct {
+		Height string            `json:"height"`
+		Result govtypes.Proposal `json:"result"`
+	}{Height: "100", Result: proposal})
+	assert.NoError(t, err)
+
+	// the phase is measured against the block time, not the clock of the test
+	client, closer := newMockClient(map[string]string{
+		"/gov/proposals/7": string(proposalJSON),
+		"/blocks/latest":   `{"block_id":{},"block":{"header":{"height":"100","time":"2021-06-01T00:00:00Z"}}}`,
+	})
+	defer closer()
+
+	phase, left, err := NewGovernanceService(client).GetProposalPhase(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, ProposalPhaseVoting, phase)
+	assert.Equal(t, 12*time.Hour, left)
+
+	_, _, err = NewGovernanceService(client).GetProposalPhase(context.Background(), 8)
+	assert.True(t, httpclient.IsNotFound(err))
+}
+
+func TestGetVotesWithValidatorMapping(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	bonded, jailed := cosmostypes.ValAddress(mockAddress(1)), cosmostypes.ValAddress(mockAddress(2))
+
+	// the validator votes land on the second page, among delegator votes
+	votes := make(govtypes.Votes, votesPageLimit+2)
+	for i := range votes {
+		votes[i] = govtypes.NewVote(7, mockAddress(byte(i+10)), govtypes.OptionYes)
+	}
+	votes[votesPageLimit] = govtypes.NewVote(7, cosmostypes.AccAddress(jailed), govtypes.OptionNoWithVeto)
+	votes[votesPageLimit+1] = govtypes.NewVote(7, cosmostypes.AccAddress(bonded), govtypes.OptionAbstain)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/gov/proposals/7/votes":
+			page := votes[:votesPageLimit]
+			if r.URL.Query().Get("page") == "2" {
+				page = votes[votesPageLimit:]
+			}
+			bz, err := cdc.MarshalJSON(struct {
+				Height string         `json:"height"`
+				Result govtypes.Votes `json:"result"`
+			}{Height: "100", Result: page})
+			assert.NoError(t, err)
+			_, _ = w.Write(bz)
+		case "/staking/validators":
+			var validators []string
+			switch r.URL.Query().Get("status") {
+			case string(ValidatorStatusBonded):
+				validators = append(validators, mockValidator(bonded, 2, false, "100", "100"))
+			case string(ValidatorStatusUnbonded):
+				validators = append(validators, mockValidator(jailed, 0, true, "10", "10"))
+			}
+			_, _ = w.Write([]byte(`{"height":"100","result":[` + strings.Join(validators, ",") + `]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	result, err := NewGovernanceService(httpclient.New(nil, server.URL)).
+		GetVotesWithValidatorMapping(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []ValidatorVote{
+		{Validator: jailed, Moniker: "validator", Option: govtypes.OptionNoWithVeto},
+		{Validator: bonded, Moniker: "validator", Option: govtypes.OptionAbstain},
+	}, result)
+}
+
+func TestGetVotesIgnoredPaging(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	votes := make(govtypes.Votes, votesPageLimit)
+	for i := range votes {
+		votes[i] = govtypes.NewVote(7, mockAddress(byte(i+10)), govtypes.OptionYes)
+	}
+
+	// old LCDs answer every page with the same votes
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		bz, err := cdc.MarshalJSON(struct {
+			Height string         `json:"height"`
+			Result govtypes.Votes `json:"result"`
+		}{Height: "100", Result: votes})
+		assert.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(bz)
+	}))
+	defer server.Close()
+
+	result, err := NewGovernanceService(httpclient.New(nil, server.URL)).GetVotes(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, votes, result)
+	assert.Equal(t, 2, requests)
+}
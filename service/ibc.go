@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// TransferVersion is the ICS-20 version the escrow addresses are derived with.
+const TransferVersion = "ics20-1"
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_ibc.go . IBCService
+type IBCService interface {
+	GetChannel(ctx context.Context, port, channel string) (IBCChannel, error)
+	GetEscrowBalance(ctx context.Context, port, channel string) (cosmostypes.Coins, error)
+}
+
+type ibcService struct {
+	codec  *codec.Codec
+	client httpclient.Client
+	bank   BankService
+}
+
+func NewIBCService(client httpclient.Client) IBCService {
+	return ibcService{
+		codec:  client.Codec(),
+		client: client,
+		bank:   NewBankService(client),
+	}
+}
+
+func (svc ibcService) GetChannel(ctx context.Context, port, channel string) (IBCChannel, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/ibc/core/channel/v1/channels/%s/ports/%s", channel, port),
+	}
+
+	var body struct {
+		Channel IBCChannel `json:"channel"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return IBCChannel{}, errors.Wrap(err, "request json")
+	}
+	return body.Channel, nil
+}
+
+// GetEscrowBalance returns the tokens escrowed for transfers out through channel on port,
+// i.e. the sent native tokens that haven't come back.
+func (svc ibcService) GetEscrowBalance(ctx context.Context, port, channel string) (cosmostypes.Coins, error) {
+	if _, err := svc.GetChannel(ctx, port, channel); err != nil {
+		return nil, errors.Wrapf(err, "fetch channel %s/%s", port, channel)
+	}
+
+	resp, err := svc.bank.GetBalance(ctx, EscrowAddress(port, channel))
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch escrow balance")
+	}
+	return resp.Balance, nil
+}
+
+// EscrowAddress derives the transfer escrow account of channel on port, as ibc-go does.
+func EscrowAddress(port, channel string) cosmostypes.AccAddress {
+	preImage := []byte(TransferVersion)
+	preImage = append(preImage, 0)
+	preImage = append(preImage, fmt.Sprintf("%s/%s", port, channel)...)
+	hash := sha256.Sum256(preImage)
+	return hash[:20]
+}
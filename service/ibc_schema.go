@@ -0,0 +1,14 @@
+package service
+
+type IBCCounterparty struct {
+	PortID    string `json:"port_id"`
+	ChannelID string `json:"channel_id"`
+}
+
+type IBCChannel struct {
+	State          string          `json:"state"`
+	Ordering       string          `json:"ordering"`
+	Counterparty   IBCCounterparty `json:"counterparty"`
+	ConnectionHops []string        `json:"connection_hops"`
+	Version        string          `json:"version"`
+}
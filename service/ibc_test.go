@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestGetEscrowBalance(t *testing.T) {
+	escrow := EscrowAddress("transfer", "channel-1")
+	assert.Len(t, escrow, 20)
+	assert.NotEqual(t, escrow, EscrowAddress("transfer", "channel-2"))
+
+	client, closer := newMockClient(map[string]string{
+		"/ibc/core/channel/v1/channels/channel-1/ports/transfer": `{"channel":{
+			"state":"STATE_OPEN","ordering":"ORDER_UNORDERED",
+			"counterparty":{"port_id":"transfer","channel_id":"channel-229"},
+			"connection_hops":["connection-1"],"version":"ics20-1"
+		},"proof":null,"proof_height":{"revision_number":"0","revision_height":"100"}}`,
+		"/bank/balances/" + escrow.String(): `{"height":"100","result":[
+			{"denom":"uluna","amount":"5000000"},{"denom":"uusd","amount":"120000"}
+		]}`,
+	})
+	defer closer()
+
+	svc := NewIBCService(client)
+
+	channel, err := svc.GetChannel(context.Background(), "transfer", "channel-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "STATE_OPEN", channel.State)
+	assert.Equal(t, "channel-229", channel.Counterparty.ChannelID)
+
+	balance, err := svc.GetEscrowBalance(context.Background(), "transfer", "channel-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "5000000uluna,120000uusd", balance.String())
+
+	_, err = svc.GetEscrowBalance(context.Background(), "transfer", "channel-2")
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_mint.go . MintService
+type MintService interface {
+	GetInflation(ctx context.Context) (cosmostypes.Dec, error)
+	GetBondedRatio(ctx context.Context) (cosmostypes.Dec, error)
+	GetInflationTrend(ctx context.Context, heights []int64) ([]InflationPoint, error)
+	GetAnnualProvisions(ctx context.Context) (cosmostypes.Dec, error)
+	GetEpochProvisions(ctx context.Context) (cosmostypes.Dec, error)
+}
+
+type mintService struct {
+	codec      *codec.Codec
+	client     httpclient.Client
+	bank       BankService
+	staking    StakingService
+	tendermint TendermintService
+}
+
+func NewMintService(client httpclient.Client) MintService {
+	return mintService{
+		codec:      client.Codec(),
+		client:     client,
+		bank:       NewBankService(client),
+		staking:    NewStakingService(client),
+		tendermint: NewTendermintService(client),
+	}
+}
+
+func (svc mintService) GetInflation(ctx context.Context) (cosmostypes.Dec, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/minting/inflation",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint `json:"height"`
+		Result cosmostypes.Dec  `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetAnnualProvisions returns the tokens minted per year at the current rate. On chains minting per
+// epoch it's derived from the epoch provisions and the length of the mint epoch.
+func (svc mintService) GetAnnualProvisions(ctx context.Context) (cosmostypes.Dec, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/minting/annual-provisions",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint `json:"height"`
+		Result cosmostypes.Dec  `json:"result"`
+	}
+	err := svc.client.RequestJSON(payload, &body)
+	if err == nil {
+		return body.Result, nil
+	}
+	if !httpclient.IsNotFound(err) && httpclient.StatusCode(err) != http.StatusNotImplemented {
+		return cosmostypes.Dec{}, errors.Wrap(err, "request json")
+	}
+
+	provisions, err := svc.GetEpochProvisions(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch epoch provisions")
+	}
+	epochsPerYear, err := svc.epochsPerYear(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, err
+	}
+	return provisions.MulInt64(epochsPerYear), nil
+}
+
+// GetEpochProvisions returns the tokens minted at the end of each mint epoch. It fails with
+// ErrBlockBasedMinting on chains whose mint module mints every block, see GetAnnualProvisions instead.
+func (svc mintService) GetEpochProvisions(ctx context.Context) (cosmostypes.Dec, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/osmosis/mint/v1beta1/epoch_provisions",
+	}
+
+	var body struct {
+		EpochProvisions cosmostypes.Dec `json:"epoch_provisions"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		if httpclient.IsNotFound(err) || httpclient.StatusCode(err) == http.StatusNotImplemented {
+			return cosmostypes.Dec{}, ErrBlockBasedMinting
+		}
+		return cosmostypes.Dec{}, errors.Wrap(err, "request json")
+	}
+	return body.EpochProvisions, nil
+}
+
+// mintEpochsPerYear maps the epoch identifiers of the epochs module to their count in a year.
+var mintEpochsPerYear = map[string]int64{
+	"hour": 24 * 365,
+	"day":  365,
+	"week": 52,
+}
+
+func (svc mintService) epochsPerYear(ctx context.Context) (int64, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/osmosis/mint/v1beta1/params",
+	}
+
+	var body struct {
+		Params struct {
+			EpochIdentifier string `json:"epoch_identifier"`
+		} `json:"params"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return 0, errors.Wrap(err, "fetch mint params")
+	}
+
+	epochs, ok := mintEpochsPerYear[body.Params.EpochIdentifier]
+	if !ok {
+		return 0, errors.Errorf("unknown mint epoch %q", body.Params.EpochIdentifier)
+	}
+	return epochs, nil
+}
+
+// GetBondedRatio returns bonded tokens over the total supply of the bond denom, as the mint module computes it.
+func (svc mintService) GetBondedRatio(ctx context.Context) (cosmostypes.Dec, error) {
+	params, err := svc.staking.GetParams(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch staking params")
+	}
+	pool, err := svc.staking.GetPool(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch staking pool")
+	}
+	supply, err := svc.bank.GetTotalSupply(ctx, params.BondDenom)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrapf(err, "fetch total supply of %s", params.BondDenom)
+	}
+
+	if !supply.IsPositive() {
+		return cosmostypes.ZeroDec(), nil
+	}
+	return pool.BondedTokens.ToDec().QuoInt(supply), nil
+}
+
+// GetInflationTrend returns inflation and bonded ratio at each of heights, in the given order.
+// A height the node can't serve, e.g. pruned state, only sets Err on its point.
+func (svc mintService) GetInflationTrend(ctx context.Context, heights []int64) ([]InflationPoint, error) {
+	points := make([]InflationPoint, 0, len(heights))
+	for _, height := range heights {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		points = append(points, svc.inflationAt(httpclient.AtHeight(ctx, height), height))
+	}
+	return points, nil
+}
+
+func (svc mintService) inflationAt(ctx context.Context, height int64) InflationPoint {
+	point := InflationPoint{Height: height}
+
+	h := uint64(height)
+	_, block, err := svc.tendermint.GetBlockByHeight(ctx, &h)
+	if err != nil {
+		point.Err = errors.Wrapf(err, "fetch block %d", height)
+		return point
+	}
+	point.Time = block.Time
+
+	if point.Inflation, err = svc.GetInflation(ctx); err != nil {
+		point.Err = errors.Wrapf(err, "fetch inflation at %d", height)
+		return point
+	}
+	if point.BondedRatio, err = svc.GetBondedRatio(ctx); err != nil {
+		point.Err = errors.Wrapf(err, "fetch bonded ratio at %d", height)
+		return point
+	}
+	return point
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"time"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+type InflationPoint struct {
+	Height      int64           `json:"height"`
+	Time        time.Time       `json:"time"`
+	Inflation   cosmostypes.Dec `json:"inflation"`
+	BondedRatio cosmostypes.Dec `json:"bonded_ratio"`
+	Err         error           `json:"-"`
+}
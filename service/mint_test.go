@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	terraapp "github.com/terra-project/core/app"
+	"github.com/tj/assert"
+)
+
+func TestGetInflationTrend(t *testing.T) {
+	type state struct{ time, inflation, bonded, supply string }
+	states := map[string]state{
+		"100": {time: "2021-01-01T00:00:00Z", inflation: "0.070000000000000000", bonded: "500", supply: "1000"},
+		"200": {time: "2021-01-02T00:00:00Z", inflation: "0.075000000000000000", bonded: "400", supply: "1000"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		height := r.URL.Query().Get("height")
+		assert.Equal(t, height, r.Header.Get(httpclient.HeightHeader))
+
+		s, ok := states[height]
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"failed to load state at height ` + height + `; version does not exist"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		var body string
+		switch r.URL.Path {
+		case "/blocks/" + height:
+			body = `{"block_id":{},"block":{"header":{"height":"` + height + `","time":"` + s.time + `"}}}`
+		case "/minting/inflation":
+			body = `{"height":"` + height + `","result":"` + s.inflation + `"}`
+		case "/staking/parameters":
+			body = mockStakingParams
+		case "/staking/pool":
+			body = `{"height":"` + height + `","result":{"not_bonded_tokens":"100","bonded_tokens":"` + s.bonded + `"}}`
+		case "/supply/total/uluna":
+			body = `{"height":"` + height + `","result":"` + s.supply + `"}`
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	points, err := NewMintService(httpclient.New(terraapp.MakeCodec(), server.URL)).
+		GetInflationTrend(context.Background(), []int64{100, 50, 200})
+	assert.NoError(t, err)
+	assert.Len(t, points, 3)
+
+	assert.NoError(t, points[0].Err)
+	assert.Equal(t, int64(100), points[0].Height)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), points[0].Time)
+	assert.Equal(t, "0.070000000000000000", points[0].Inflation.String())
+	assert.Equal(t, "0.500000000000000000", points[0].BondedRatio.String())
+
+	assert.Error(t, points[1].Err)
+	assert.Equal(t, int64(50), points[1].Height)
+
+	assert.NoError(t, points[2].Err)
+	assert.Equal(t, time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), points[2].Time)
+	assert.Equal(t, "0.075000000000000000", points[2].Inflation.String())
+	assert.Equal(t, "0.400000000000000000", points[2].BondedRatio.String())
+}
+
+func TestGetEpochProvisions(t *testing.T) {
+	ctx := context.Background()
+	client, closeFn := newMockClient(map[string]string{
+		"/osmosis/mint/v1beta1/epoch_provisions": `{"epoch_provisions":"821917808219.178082191780821917"}`,
+		"/osmosis/mint/v1beta1/params":           `{"params":{"mint_denom":"uosmo","epoch_identifier":"day"}}`,
+	})
+	defer closeFn()
+
+	svc := NewMintService(client)
+	provisions, err := svc.GetEpochProvisions(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "821917808219.178082191780821917", provisions.String())
+
+	annual, err := svc.GetAnnualProvisions(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "299999999999999.999999999999999705", annual.String())
+
+	client, closeFn = newMockClient(map[string]string{
+		"/minting/annual-provisions": `{"height":"100","result":"1000000.000000000000000000"}`,
+	})
+	defer closeFn()
+
+	svc = NewMintService(client)
+	_, err = svc.GetEpochProvisions(ctx)
+	assert.Equal(t, ErrBlockBasedMinting, err)
+
+	annual, err = svc.GetAnnualProvisions(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000.000000000000000000", annual.String())
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraoracle "github.com/terra-project/core/x/oracle"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_oracle.go . OracleService
+type OracleService interface {
+	GetParams(ctx context.Context) (terraoracle.Params, error)
+	GetTobinTaxes(ctx context.Context) (map[string]cosmostypes.Dec, error)
+	GetTobinTax(ctx context.Context, denom string) (cosmostypes.Dec, error)
+	GetWhitelist(ctx context.Context) ([]WhitelistDenom, error)
+	GetExchangeRates(ctx context.Context) (cosmostypes.DecCoins, error)
+	GetAggregatePrevote(
+		ctx context.Context,
+		validator cosmostypes.ValAddress,
+	) (terraoracle.AggregateExchangeRatePrevote, error)
+	GetVoteWindow(ctx context.Context) (period int64, blockInPeriod int64, blocksLeft int64, err error)
+}
+
+type oracleService struct {
+	codec  *codec.Codec
+	client httpclient.Client
+
+	tendermint TendermintService
+}
+
+func NewOracleService(client httpclient.Client) OracleService {
+	return oracleService{
+		codec:  client.Codec(),
+		client: client,
+
+		tendermint: NewTendermintService(client),
+	}
+}
+
+func (svc oracleService) GetParams(ctx context.Context) (terraoracle.Params, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/oracle/parameters",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint   `json:"height"`
+		Result terraoracle.Params `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return terraoracle.Params{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetVoteWindow returns the vote period the latest block is in, the block's index within it and
+// how many blocks of the period come after it. Votes are tallied at the last block of a period.
+func (svc oracleService) GetVoteWindow(ctx context.Context) (period int64, blockInPeriod int64, blocksLeft int64, err error) {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "fetch oracle params")
+	}
+	if params.VotePeriod <= 0 {
+		return 0, 0, 0, errors.Errorf("invalid vote period %d", params.VotePeriod)
+	}
+
+	_, block, err := svc.tendermint.GetBlockByHeight(ctx, nil)
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "fetch latest block")
+	}
+
+	period = block.Height / params.VotePeriod
+	blockInPeriod = block.Height % params.VotePeriod
+	return period, blockInPeriod, params.VotePeriod - blockInPeriod - 1, nil
+}
+
+func (svc oracleService) GetTobinTaxes(ctx context.Context) (map[string]cosmostypes.Dec, error) {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch oracle params")
+	}
+
+	tobinTaxes := make(map[string]cosmostypes.Dec, len(params.Whitelist))
+	for _, denom := range params.Whitelist {
+		tobinTaxes[denom.Name] = denom.TobinTax
+	}
+	return tobinTaxes, nil
+}
+
+// GetWhitelist returns the denoms the oracle votes on, which are the ones the market module can swap,
+// in the order of the oracle params.
+func (svc oracleService) GetWhitelist(ctx context.Context) ([]WhitelistDenom, error) {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch oracle params")
+	}
+
+	whitelist := make([]WhitelistDenom, len(params.Whitelist))
+	for i, denom := range params.Whitelist {
+		whitelist[i] = WhitelistDenom{Denom: denom.Name, TobinTax: denom.TobinTax}
+	}
+	return whitelist, nil
+}
+
+// DiffWhitelist reports the denoms of current that aren't in old and the other way round, e.g. between
+// two polls of GetWhitelist to react to governance adding a denom. A changed tobin tax isn't reported.
+func DiffWhitelist(old, current []WhitelistDenom) WhitelistDiff {
+	return WhitelistDiff{
+		Added:   missingDenoms(current, old),
+		Removed: missingDenoms(old, current),
+	}
+}
+
+// missingDenoms returns the denoms of whitelist that others doesn't have.
+func missingDenoms(whitelist, others []WhitelistDenom) []WhitelistDenom {
+	present := make(map[string]struct{}, len(others))
+	for _, denom := range others {
+		present[denom.Denom] = struct{}{}
+	}
+
+	missing := make([]WhitelistDenom, 0)
+	for _, denom := range whitelist {
+		if _, ok := present[denom.Denom]; !ok {
+			missing = append(missing, denom)
+		}
+	}
+	return missing
+}
+
+func (svc oracleService) GetTobinTax(ctx context.Context, denom string) (cosmostypes.Dec, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/oracle/denoms/%s/tobin_tax", denom),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint `json:"height"`
+		Result cosmostypes.Dec  `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetExchangeRates returns the price of one LUNA in each whitelisted denom.
+func (svc oracleService) GetExchangeRates(ctx context.Context) (cosmostypes.DecCoins, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/oracle/denoms/exchange_rates",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint     `json:"height"`
+		Result cosmostypes.DecCoins `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetAggregatePrevote returns the prevote validator committed in the previous vote period,
+// or ErrAggregatePrevoteNotFound if there is none to reveal.
+func (svc oracleService) GetAggregatePrevote(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+) (terraoracle.AggregateExchangeRatePrevote, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/oracle/voters/%s/aggregate_prevote", valAddress(svc.client, validator)),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint                         `json:"height"`
+		Result terraoracle.AggregateExchangeRatePrevote `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		// the keeper answers with "no aggregate prevote" and a 500 rather than a 404
+		if httpclient.IsNotFound(err) || strings.Contains(err.Error(), "no aggregate prevote") {
+			return terraoracle.AggregateExchangeRatePrevote{}, errors.Wrap(ErrAggregatePrevoteNotFound, validator.String())
+		}
+		return terraoracle.AggregateExchangeRatePrevote{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
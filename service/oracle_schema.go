@@ -0,0 +1,13 @@
+package service
+
+import cosmostypes "github.com/cosmos/cosmos-sdk/types"
+
+type WhitelistDenom struct {
+	Denom    string          `json:"denom"`
+	TobinTax cosmostypes.Dec `json:"tobin_tax"`
+}
+
+type WhitelistDiff struct {
+	Added   []WhitelistDenom `json:"added"`
+	Removed []WhitelistDenom `json:"removed"`
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tj/assert"
+)
+
+const mockOracleParams = `{"height":"100","result":{
+	"vote_period":"5",
+	"vote_threshold":"0.500000000000000000",
+	"reward_band":"0.020000000000000000",
+	"reward_distribution_window":"5256000",
+	"whitelist":[
+		{"name":"ukrw","tobin_tax":"0.002500000000000000"},
+		{"name":"uusd","tobin_tax":"0.002500000000000000"},
+		{"name":"umnt","tobin_tax":"0.020000000000000000"}
+	],
+	"slash_fraction":"0.000100000000000000",
+	"slash_window":"100800",
+	"min_valid_per_window":"0.050000000000000000"
+}}`
+
+func TestGetTobinTaxes(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/oracle/parameters": mockOracleParams,
+	})
+	defer closer()
+
+	tobinTaxes, err := NewOracleService(client).GetTobinTaxes(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, tobinTaxes, 3)
+	assert.Equal(t, "0.002500000000000000", tobinTaxes["ukrw"].String())
+	assert.Equal(t, "0.020000000000000000", tobinTaxes["umnt"].String())
+}
+
+func TestGetVoteWindow(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/oracle/parameters": mockOracleParams,
+		"/blocks/latest":     `{"block_id":{},"block":{"header":{"height":"1002"}}}`,
+	})
+	defer closer()
+
+	period, blockInPeriod, blocksLeft, err := NewOracleService(client).GetVoteWindow(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200), period)
+	assert.Equal(t, int64(2), blockInPeriod)
+	assert.Equal(t, int64(2), blocksLeft)
+}
+
+func TestGetAggregatePrevote(t *testing.T) {
+	ctx := context.Background()
+	voter := cosmostypes.ValAddress(mockAddress(1))
+	absent := cosmostypes.ValAddress(mockAddress(2))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oracle/voters/"+voter.String()+"/aggregate_prevote" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"no aggregate prevote"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"100","result":{
+			"hash":"6a1b3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b",
+			"voter":"` + voter.String() + `",
+			"submit_block":"98"
+		}}`))
+	}))
+	defer server.Close()
+	svc := NewOracleService(httpclient.New(nil, server.URL))
+
+	prevote, err := svc.GetAggregatePrevote(ctx, voter)
+	assert.NoError(t, err)
+	assert.Equal(t, "6a1b3c5d7e9f0a2b4c6d8e0f1a3b5c7d9e1f3a5b", prevote.Hash.String())
+	assert.Equal(t, voter, prevote.Voter)
+	assert.Equal(t, int64(98), prevote.SubmitBlock)
+
+	_, err = svc.GetAggregatePrevote(ctx, absent)
+	assert.True(t, errors.Is(err, ErrAggregatePrevoteNotFound))
+}
+
+func TestDiffWhitelist(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/oracle/parameters": mockOracleParams,
+	})
+	defer closer()
+
+	whitelist, err := NewOracleService(client).GetWhitelist(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, whitelist, 3)
+	assert.Equal(t, "ukrw", whitelist[0].Denom)
+	assert.Equal(t, "0.020000000000000000", whitelist[2].TobinTax.String())
+
+	// uusd was added since the previous poll, which had usdr whitelisted
+	previous := []WhitelistDenom{whitelist[0], whitelist[2], {Denom: "usdr", TobinTax: cosmostypes.ZeroDec()}}
+	diff := DiffWhitelist(previous, whitelist)
+	assert.Equal(t, []WhitelistDenom{whitelist[1]}, diff.Added)
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, "usdr", diff.Removed[0].Denom)
+
+	diff = DiffWhitelist(whitelist, whitelist)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+}
@@ -0,0 +1,7 @@
+package service
+
+// Pagination is the page info returned by the grpc-gateway (/cosmos/...) endpoints.
+type Pagination struct {
+	NextKey string `json:"next_key"`
+	Total   string `json:"total"`
+}
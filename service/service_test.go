@@ -0,0 +1,22 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cawabunga/terra.go/httpclient"
+)
+
+// newMockClient serves the given raw bodies by request path and 404s anything else.
+func newMockClient(routes map[string]string) (httpclient.Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	return httpclient.New(nil, server.URL), server.Close
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_slashing.go . SlashingService
+type SlashingService interface {
+	GetParams(ctx context.Context) (slashingtypes.Params, error)
+	GetJailedValidators(ctx context.Context) ([]cosmostypes.ValAddress, error)
+	GetSigningInfo(ctx context.Context, consAddr cosmostypes.ConsAddress) (slashingtypes.ValidatorSigningInfo, error)
+	GetUptime(ctx context.Context, consAddr cosmostypes.ConsAddress) (cosmostypes.Dec, error)
+}
+
+const signingInfosPageLimit = 100
+
+type slashingService struct {
+	codec   *codec.Codec
+	client  httpclient.Client
+	staking StakingService
+}
+
+func NewSlashingService(client httpclient.Client) SlashingService {
+	return slashingService{
+		codec:   client.Codec(),
+		client:  client,
+		staking: NewStakingService(client),
+	}
+}
+
+func (svc slashingService) GetParams(ctx context.Context) (slashingtypes.Params, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/slashing/parameters",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint     `json:"height"`
+		Result slashingtypes.Params `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return slashingtypes.Params{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+func (svc slashingService) GetJailedValidators(ctx context.Context) ([]cosmostypes.ValAddress, error) {
+	validators, err := svc.staking.GetAllValidators(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch validators")
+	}
+
+	var jailed []cosmostypes.ValAddress
+	for _, validator := range validators {
+		if validator.Jailed {
+			jailed = append(jailed, validator.OperatorAddress)
+		}
+	}
+	return jailed, nil
+}
+
+// GetSigningInfo looks consAddr up in the signing infos, since the legacy LCD only lists them.
+func (svc slashingService) GetSigningInfo(
+	ctx context.Context,
+	consAddr cosmostypes.ConsAddress,
+) (slashingtypes.ValidatorSigningInfo, error) {
+	for page := 1; ; page++ {
+		var payload = httpclient.RequestPayload{
+			Context: ctx,
+			Method:  http.MethodGet,
+			Path:    "/slashing/signing_infos",
+			Query: map[string]string{
+				"page":  strconv.Itoa(page),
+				"limit": strconv.Itoa(signingInfosPageLimit),
+			},
+		}
+
+		var body struct {
+			Height cosmostypes.Uint                     `json:"height"`
+			Result []slashingtypes.ValidatorSigningInfo `json:"result"`
+		}
+		if err := svc.client.RequestJSON(payload, &body); err != nil {
+			return slashingtypes.ValidatorSigningInfo{}, errors.Wrapf(err, "request json of page %d", page)
+		}
+		for _, info := range body.Result {
+			if info.Address.Equals(consAddr) {
+				return info, nil
+			}
+		}
+
+		if len(body.Result) < signingInfosPageLimit {
+			return slashingtypes.ValidatorSigningInfo{}, errors.Wrap(ErrSigningInfoNotFound, consAddr.String())
+		}
+	}
+}
+
+// GetUptime returns the share of blocks consAddr signed in the current signed blocks window.
+// A validator that hasn't filled a window yet is measured over the blocks it has been in the set for.
+func (svc slashingService) GetUptime(ctx context.Context, consAddr cosmostypes.ConsAddress) (cosmostypes.Dec, error) {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch slashing params")
+	}
+	info, err := svc.GetSigningInfo(ctx, consAddr)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch signing info")
+	}
+
+	// the index offset keeps counting past the window, which the missed blocks are tracked over
+	window := info.IndexOffset
+	if window > params.SignedBlocksWindow {
+		window = params.SignedBlocksWindow
+	}
+	if window <= 0 {
+		return cosmostypes.OneDec(), nil
+	}
+	missed := cosmostypes.NewDec(info.MissedBlocksCounter).QuoInt64(window)
+	return cosmostypes.OneDec().Sub(missed), nil
+}
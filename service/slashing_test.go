@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tj/assert"
+)
+
+func TestGetJailedValidators(t *testing.T) {
+	active := cosmostypes.ValAddress(mockAddress(1))
+	jailedUnbonding := cosmostypes.ValAddress(mockAddress(2))
+	jailedUnbonded := cosmostypes.ValAddress(mockAddress(3))
+
+	validatorsByStatus := map[string]string{
+		"bonded":    `[` + mockValidator(active, 2, false, "1000", "1000") + `]`,
+		"unbonding": `[` + mockValidator(jailedUnbonding, 1, true, "1000", "1000") + `]`,
+		"unbonded":  `[` + mockValidator(jailedUnbonded, 0, true, "1000", "1000") + `]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"100","result":` + validatorsByStatus[r.URL.Query().Get("status")] + `}`))
+	}))
+	defer server.Close()
+
+	jailed, err := NewSlashingService(httpclient.New(nil, server.URL)).GetJailedValidators(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []cosmostypes.ValAddress{jailedUnbonding, jailedUnbonded}, jailed)
+}
+
+func TestGetUptime(t *testing.T) {
+	ctx := context.Background()
+
+	established := cosmostypes.ConsAddress(mockAddress(1))
+	fresh := cosmostypes.ConsAddress(mockAddress(2))
+
+	signingInfo := func(addr cosmostypes.ConsAddress, indexOffset, missed int) string {
+		return fmt.Sprintf(`{
+			"address":"%s","start_height":"0","index_offset":"%d",
+			"jailed_until":"1970-01-01T00:00:00Z","tombstoned":false,"missed_blocks_counter":"%d"
+		}`, addr.String(), indexOffset, missed)
+	}
+	client, closer := newMockClient(map[string]string{
+		"/slashing/parameters": `{"height":"100","result":{
+			"signed_blocks_window":"10000","min_signed_per_window":"0.050000000000000000",
+			"downtime_jail_duration":"600000000000",
+			"slash_fraction_double_sign":"0.050000000000000000","slash_fraction_downtime":"0.000100000000000000"
+		}}`,
+		"/slashing/signing_infos": `{"height":"100","result":[` +
+			signingInfo(established, 123456, 250) + `,` +
+			signingInfo(fresh, 400, 4) +
+			`]}`,
+	})
+	defer closer()
+
+	svc := NewSlashingService(client)
+
+	uptime, err := svc.GetUptime(ctx, established)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.975000000000000000", uptime.String())
+
+	uptime, err = svc.GetUptime(ctx, fresh)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.990000000000000000", uptime.String())
+
+	_, err = svc.GetUptime(ctx, cosmostypes.ConsAddress(mockAddress(3)))
+	assert.True(t, errors.Is(err, ErrSigningInfoNotFound))
+}
@@ -0,0 +1,678 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/pkg/errors"
+)
+
+//go:generate mockgen -destination ../../../test/mocks/terra/service/service_staking.go . StakingService
+type StakingService interface {
+	GetParams(ctx context.Context) (stakingtypes.Params, error)
+	GetPool(ctx context.Context) (stakingtypes.Pool, error)
+	GetValidator(ctx context.Context, validator cosmostypes.ValAddress) (stakingtypes.Validator, error)
+	GetValidatorForAccount(ctx context.Context, account cosmostypes.AccAddress) (*stakingtypes.Validator, error)
+	GetValidators(ctx context.Context, status ValidatorStatus) (stakingtypes.Validators, error)
+	GetAllValidators(ctx context.Context) (stakingtypes.Validators, error)
+	GetValidatorCounts(ctx context.Context) (active int, total int, max int, err error)
+	GetDelegations(ctx context.Context, delegator cosmostypes.AccAddress) (stakingtypes.DelegationResponses, error)
+	GetDelegationBalances(ctx context.Context, delegator cosmostypes.AccAddress) ([]DelegationBalance, error)
+	GetTotalDelegatedTokens(ctx context.Context, delegator cosmostypes.AccAddress) (cosmostypes.Int, error)
+	GetUnbondingDelegations(
+		ctx context.Context,
+		delegator cosmostypes.AccAddress,
+	) ([]stakingtypes.UnbondingDelegation, error)
+	GetValidatorUnbondingDelegations(
+		ctx context.Context,
+		validator cosmostypes.ValAddress,
+	) ([]stakingtypes.UnbondingDelegation, error)
+	GetRedelegations(ctx context.Context, delegator cosmostypes.AccAddress) (stakingtypes.RedelegationResponses, error)
+	CheckUndelegate(ctx context.Context, delegator cosmostypes.AccAddress, validator cosmostypes.ValAddress) error
+	CheckRedelegate(
+		ctx context.Context,
+		delegator cosmostypes.AccAddress,
+		src, dst cosmostypes.ValAddress,
+	) error
+	GetPendingOperations(ctx context.Context, delegator cosmostypes.AccAddress) ([]PendingOperation, error)
+	GetCommissionHistory(ctx context.Context, validator cosmostypes.ValAddress) ([]CommissionChange, error)
+	GetValidatorNetAPR(ctx context.Context, validator cosmostypes.ValAddress) (cosmostypes.Dec, error)
+	GetValidatorStatusChanges(
+		ctx context.Context,
+		validator cosmostypes.ValAddress,
+		heights []int64,
+	) ([]StatusChange, error)
+}
+
+const (
+	validatorsPageLimit           = 100
+	txSearchPageLimit             = 100
+	unbondingDelegationsPageLimit = 100
+)
+
+type stakingService struct {
+	codec        *codec.Codec
+	client       httpclient.Client
+	transaction  TransactionService
+	bank         BankService
+	distribution DistributionService
+}
+
+func NewStakingService(client httpclient.Client) StakingService {
+	return stakingService{
+		codec:        client.Codec(),
+		client:       client,
+		transaction:  NewTransactionService(client),
+		bank:         NewBankService(client),
+		distribution: NewDistributionService(client),
+	}
+}
+
+func (svc stakingService) GetParams(ctx context.Context) (stakingtypes.Params, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/staking/parameters",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint    `json:"height"`
+		Result stakingtypes.Params `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return stakingtypes.Params{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+func (svc stakingService) GetPool(ctx context.Context) (stakingtypes.Pool, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/staking/pool",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint  `json:"height"`
+		Result stakingtypes.Pool `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return stakingtypes.Pool{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+func (svc stakingService) GetValidator(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+) (stakingtypes.Validator, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/staking/validators/%s", valAddress(svc.client, validator)),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint       `json:"height"`
+		Result stakingtypes.Validator `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return stakingtypes.Validator{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetValidatorForAccount returns the validator account operates, or nil if it doesn't operate one.
+func (svc stakingService) GetValidatorForAccount(
+	ctx context.Context,
+	account cosmostypes.AccAddress,
+) (*stakingtypes.Validator, error) {
+	validator, err := svc.GetValidator(ctx, cosmostypes.ValAddress(account))
+	if err != nil {
+		// the keeper answers with "validator does not exist" and a 500 rather than a 404
+		if httpclient.IsNotFound(err) || strings.Contains(err.Error(), "validator does not exist") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &validator, nil
+}
+
+func (svc stakingService) GetValidators(
+	ctx context.Context,
+	status ValidatorStatus,
+) (stakingtypes.Validators, error) {
+	var validators stakingtypes.Validators
+	for page := 1; ; page++ {
+		var payload = httpclient.RequestPayload{
+			Context: ctx,
+			Method:  http.MethodGet,
+			Path:    "/staking/validators",
+			Query: map[string]string{
+				"status": string(status),
+				"page":   strconv.Itoa(page),
+				"limit":  strconv.Itoa(validatorsPageLimit),
+			},
+		}
+
+		var body struct {
+			Height cosmostypes.Uint        `json:"height"`
+			Result stakingtypes.Validators `json:"result"`
+		}
+		if err := svc.client.RequestJSON(payload, &body); err != nil {
+			return nil, errors.Wrap(err, "request json")
+		}
+		validators = append(validators, body.Result...)
+
+		if len(body.Result) < validatorsPageLimit {
+			return validators, nil
+		}
+	}
+}
+
+func (svc stakingService) GetAllValidators(ctx context.Context) (stakingtypes.Validators, error) {
+	var validators stakingtypes.Validators
+	for _, status := range []ValidatorStatus{ValidatorStatusBonded, ValidatorStatusUnbonding, ValidatorStatusUnbonded} {
+		vals, err := svc.GetValidators(ctx, status)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetch %s validators", status)
+		}
+		validators = append(validators, vals...)
+	}
+	return validators, nil
+}
+
+// GetValidatorCounts returns the bonded validator count, the count of every validator
+// regardless of status, and the max_validators param capping the active set.
+func (svc stakingService) GetValidatorCounts(ctx context.Context) (active int, total int, max int, err error) {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return 0, 0, 0, errors.Wrap(err, "fetch staking params")
+	}
+
+	for _, status := range []ValidatorStatus{ValidatorStatusBonded, ValidatorStatusUnbonding, ValidatorStatusUnbonded} {
+		validators, err := svc.GetValidators(ctx, status)
+		if err != nil {
+			return 0, 0, 0, errors.Wrapf(err, "fetch %s validators", status)
+		}
+		if status == ValidatorStatusBonded {
+			active = len(validators)
+		}
+		total += len(validators)
+	}
+	return active, total, int(params.MaxValidators), nil
+}
+
+func (svc stakingService) GetDelegations(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) (stakingtypes.DelegationResponses, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/staking/delegators/%s/delegations", accAddress(svc.client, delegator)),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint                 `json:"height"`
+		Result stakingtypes.DelegationResponses `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetDelegationBalances values each delegation with its validator's current tokens/shares
+// exchange rate, so slashed validators are reflected in the balance.
+func (svc stakingService) GetDelegationBalances(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) ([]DelegationBalance, error) {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch staking params")
+	}
+
+	delegations, err := svc.GetDelegations(ctx, delegator)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch delegations of %s", delegator.String())
+	}
+
+	balances := make([]DelegationBalance, 0, len(delegations))
+	for _, delegation := range delegations {
+		validator, err := svc.GetValidator(ctx, delegation.ValidatorAddress)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetch validator %s", delegation.ValidatorAddress.String())
+		}
+
+		tokens := cosmostypes.ZeroInt()
+		if !validator.DelegatorShares.IsZero() {
+			tokens = validator.TokensFromShares(delegation.Shares).TruncateInt()
+		}
+		balances = append(balances, DelegationBalance{
+			DelegatorAddress: delegation.DelegatorAddress,
+			ValidatorAddress: delegation.ValidatorAddress,
+			Shares:           delegation.Shares,
+			Balance:          cosmostypes.NewCoin(params.BondDenom, tokens),
+		})
+	}
+	return balances, nil
+}
+
+// GetTotalDelegatedTokens returns the bond denom tokens all delegations of delegator are worth,
+// without the per-validator breakdown of GetDelegationBalances.
+func (svc stakingService) GetTotalDelegatedTokens(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) (cosmostypes.Int, error) {
+	delegations, err := svc.GetDelegations(ctx, delegator)
+	if err != nil {
+		return cosmostypes.Int{}, errors.Wrapf(err, "fetch delegations of %s", delegator.String())
+	}
+
+	total := cosmostypes.ZeroDec()
+	for _, delegation := range delegations {
+		validator, err := svc.GetValidator(ctx, delegation.ValidatorAddress)
+		if err != nil {
+			return cosmostypes.Int{}, errors.Wrapf(err, "fetch validator %s", delegation.ValidatorAddress.String())
+		}
+		if validator.DelegatorShares.IsZero() {
+			continue
+		}
+		total = total.Add(validator.TokensFromShares(delegation.Shares))
+	}
+	return total.TruncateInt(), nil
+}
+
+func (svc stakingService) GetUnbondingDelegations(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) ([]stakingtypes.UnbondingDelegation, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    fmt.Sprintf("/staking/delegators/%s/unbonding_delegations", accAddress(svc.client, delegator)),
+	}
+
+	var body struct {
+		Height cosmostypes.Uint                   `json:"height"`
+		Result []stakingtypes.UnbondingDelegation `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetValidatorUnbondingDelegations returns the undelegations from validator that are still unbonding.
+// Legacy LCD versions that ignore page and limit return the whole list on every page,
+// so paging stops at the first page that starts with the delegator of the page before.
+func (svc stakingService) GetValidatorUnbondingDelegations(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+) ([]stakingtypes.UnbondingDelegation, error) {
+	var unbondings []stakingtypes.UnbondingDelegation
+	var first cosmostypes.AccAddress
+	for page := 1; ; page++ {
+		var payload = httpclient.RequestPayload{
+			Context: ctx,
+			Method:  http.MethodGet,
+			Path:    fmt.Sprintf("/staking/validators/%s/unbonding_delegations", valAddress(svc.client, validator)),
+			Query: map[string]string{
+				"page":  strconv.Itoa(page),
+				"limit": strconv.Itoa(unbondingDelegationsPageLimit),
+			},
+		}
+
+		var body struct {
+			Height cosmostypes.Uint                   `json:"height"`
+			Result []stakingtypes.UnbondingDelegation `json:"result"`
+		}
+		if err := svc.client.RequestJSON(payload, &body); err != nil {
+			return nil, errors.Wrap(err, "request json")
+		}
+		if len(body.Result) < unbondingDelegationsPageLimit {
+			return append(unbondings, body.Result...), nil
+		}
+		if body.Result[0].DelegatorAddress.Equals(first) {
+			return unbondings, nil
+		}
+		first = body.Result[0].DelegatorAddress
+		unbondings = append(unbondings, body.Result...)
+	}
+}
+
+// CheckUndelegate fails with ErrMaxEntriesExceeded if delegator already has max_entries
+// undelegations from validator in progress, so another one would be rejected.
+func (svc stakingService) CheckUndelegate(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+	validator cosmostypes.ValAddress,
+) error {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetch staking params")
+	}
+	unbondings, err := svc.GetUnbondingDelegations(ctx, delegator)
+	if err != nil {
+		return errors.Wrapf(err, "fetch unbonding delegations of %s", delegator.String())
+	}
+
+	for _, unbonding := range unbondings {
+		if unbonding.ValidatorAddress.Equals(validator) && len(unbonding.Entries) >= int(params.MaxEntries) {
+			return errors.Wrapf(
+				ErrMaxEntriesExceeded,
+				"%d undelegations from %s in progress",
+				len(unbonding.Entries), validator.String(),
+			)
+		}
+	}
+	return nil
+}
+
+// CheckRedelegate is CheckUndelegate for redelegations from src to dst.
+func (svc stakingService) CheckRedelegate(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+	src, dst cosmostypes.ValAddress,
+) error {
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetch staking params")
+	}
+	redelegations, err := svc.GetRedelegations(ctx, delegator)
+	if err != nil {
+		return errors.Wrapf(err, "fetch redelegations of %s", delegator.String())
+	}
+
+	for _, redelegation := range redelegations {
+		if redelegation.ValidatorSrcAddress.Equals(src) &&
+			redelegation.ValidatorDstAddress.Equals(dst) &&
+			len(redelegation.Entries) >= int(params.MaxEntries) {
+			return errors.Wrapf(
+				ErrMaxEntriesExceeded,
+				"%d redelegations from %s to %s in progress",
+				len(redelegation.Entries), src.String(), dst.String(),
+			)
+		}
+	}
+	return nil
+}
+
+func (svc stakingService) GetRedelegations(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) (stakingtypes.RedelegationResponses, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/staking/redelegations",
+		Query:   map[string]string{"delegator": accAddress(svc.client, delegator)},
+	}
+
+	var body struct {
+		Height cosmostypes.Uint                   `json:"height"`
+		Result stakingtypes.RedelegationResponses `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return nil, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+// GetPendingOperations merges in-progress unbondings and redelegations into one timeline ordered by completion time.
+func (svc stakingService) GetPendingOperations(
+	ctx context.Context,
+	delegator cosmostypes.AccAddress,
+) ([]PendingOperation, error) {
+	unbondings, err := svc.GetUnbondingDelegations(ctx, delegator)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch unbonding delegations of %s", delegator.String())
+	}
+
+	redelegations, err := svc.GetRedelegations(ctx, delegator)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch redelegations of %s", delegator.String())
+	}
+
+	var ops []PendingOperation
+	for _, unbonding := range unbondings {
+		for _, entry := range unbonding.Entries {
+			ops = append(ops, PendingOperation{
+				Type:            PendingOperationUnbonding,
+				Balance:         entry.Balance,
+				SourceValidator: unbonding.ValidatorAddress,
+				CreationHeight:  entry.CreationHeight,
+				CompletionTime:  entry.CompletionTime,
+			})
+		}
+	}
+	for _, redelegation := range redelegations {
+		for _, entry := range redelegation.Entries {
+			ops = append(ops, PendingOperation{
+				Type:                 PendingOperationRedelegation,
+				Balance:              entry.Balance,
+				SourceValidator:      redelegation.ValidatorSrcAddress,
+				DestinationValidator: redelegation.ValidatorDstAddress,
+				CreationHeight:       entry.CreationHeight,
+				CompletionTime:       entry.CompletionTime,
+			})
+		}
+	}
+
+	sort.SliceStable(ops, func(i, j int) bool {
+		return ops[i].CompletionTime.Before(ops[j].CompletionTime)
+	})
+	return ops, nil
+}
+
+// GetCommissionHistory searches the MsgEditValidator txs sent by validator and returns
+// its commission rate changes in height order. Edits that leave the rate untouched are skipped.
+func (svc stakingService) GetCommissionHistory(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+) ([]CommissionChange, error) {
+	changes := make([]CommissionChange, 0)
+	for page := int64(1); ; page++ {
+		p, limit := page, int64(txSearchPageLimit)
+		resp, err := svc.transaction.QueryTx(ctx, QueryTxRequest{
+			Page:  &p,
+			Limit: &limit,
+			Query: types.Q{
+				"message.action": "edit_validator",
+				"message.sender": accAddress(svc.client, cosmostypes.AccAddress(validator)),
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "search edit_validator txs of page %d", page)
+		}
+
+		for _, txResp := range resp.Txs {
+			tx, err := DecodedTx(txResp)
+			if err != nil {
+				return nil, errors.Wrapf(err, "decode tx %s", txResp.TxHash)
+			}
+			timestamp, err := types.ParseBlockTime(txResp.Timestamp)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse timestamp of tx %s", txResp.TxHash)
+			}
+
+			for _, msg := range tx.Msgs {
+				edit, ok := msg.(stakingtypes.MsgEditValidator)
+				if !ok || edit.CommissionRate == nil || !edit.ValidatorAddress.Equals(validator) {
+					continue
+				}
+				changes = append(changes, CommissionChange{
+					Height:         txResp.Height,
+					TxHash:         txResp.TxHash,
+					Timestamp:      timestamp,
+					CommissionRate: *edit.CommissionRate,
+				})
+			}
+		}
+
+		if resp.PageTotal.IsNil() || resp.PageTotal.Int64() <= page {
+			break
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Height < changes[j].Height })
+	return changes, nil
+}
+
+// GetValidatorNetAPR returns the APR a delegator of validator earns from minted provisions after the
+// community tax and the validator's commission. Terra also pays out fees and swap spreads, which vary
+// block by block and aren't included.
+func (svc stakingService) GetValidatorNetAPR(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+) (cosmostypes.Dec, error) {
+	info, err := svc.GetValidator(ctx, validator)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrapf(err, "fetch validator %s", validator.String())
+	}
+	gross, err := svc.stakingAPR(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, err
+	}
+	return NetAPR(gross, info.Commission.Rate), nil
+}
+
+// NetAPR is the part of gross APR left to a delegator once the validator takes its commission.
+func NetAPR(gross, commission cosmostypes.Dec) cosmostypes.Dec {
+	return gross.Mul(cosmostypes.OneDec().Sub(commission))
+}
+
+// stakingAPR is the yearly inflation, less the community tax, spread over the bonded tokens.
+func (svc stakingService) stakingAPR(ctx context.Context) (cosmostypes.Dec, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/minting/inflation",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint `json:"height"`
+		Result cosmostypes.Dec  `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch inflation")
+	}
+	communityTax, err := svc.distribution.GetCommunityTax(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch community tax")
+	}
+
+	params, err := svc.GetParams(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch staking params")
+	}
+	pool, err := svc.GetPool(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch staking pool")
+	}
+	supply, err := svc.bank.GetTotalSupply(ctx, params.BondDenom)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrapf(err, "fetch total supply of %s", params.BondDenom)
+	}
+	if !pool.BondedTokens.IsPositive() {
+		return cosmostypes.ZeroDec(), nil
+	}
+
+	// inflation / (bonded / supply)
+	return body.Result.Mul(cosmostypes.OneDec().Sub(communityTax)).MulInt(supply).QuoInt(pool.BondedTokens), nil
+}
+
+// GetValidatorStatusChanges returns the heights at which validator's bond status changed, oldest first,
+// empty if it kept one status throughout. Status changes happen in end blockers rather than txs, so
+// the status is read at each of heights, in ascending order, and bisected down to the exact height
+// between two that differ. A change reverted between two neighbouring heights is missed, so pass
+// heights no further apart than the shortest status the caller wants to see. Before the validator was
+// created it counts as unbonded. It fails with ErrHeightPruned if the node lacks the state at a height.
+func (svc stakingService) GetValidatorStatusChanges(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+	heights []int64,
+) ([]StatusChange, error) {
+	changes := make([]StatusChange, 0)
+	if len(heights) == 0 {
+		return changes, nil
+	}
+
+	prev, err := svc.statusAt(ctx, validator, heights[0])
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(heights); i++ {
+		status, err := svc.statusAt(ctx, validator, heights[i])
+		if err != nil {
+			return nil, err
+		}
+		if status != prev {
+			if changes, err = svc.bisectStatus(ctx, validator, heights[i-1], heights[i], prev, status, changes); err != nil {
+				return nil, err
+			}
+		}
+		prev = status
+	}
+	return changes, nil
+}
+
+// bisectStatus appends the changes between low, at status from, and high, at status to.
+func (svc stakingService) bisectStatus(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+	low, high int64,
+	from, to cosmostypes.BondStatus,
+	changes []StatusChange,
+) ([]StatusChange, error) {
+	if high-low <= 1 {
+		return append(changes, StatusChange{Height: high, From: from, To: to}), nil
+	}
+
+	mid := low + (high-low)/2
+	status, err := svc.statusAt(ctx, validator, mid)
+	if err != nil {
+		return nil, err
+	}
+	if status != from {
+		if changes, err = svc.bisectStatus(ctx, validator, low, mid, from, status, changes); err != nil {
+			return nil, err
+		}
+	}
+	if status != to {
+		if changes, err = svc.bisectStatus(ctx, validator, mid, high, status, to, changes); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func (svc stakingService) statusAt(
+	ctx context.Context,
+	validator cosmostypes.ValAddress,
+	height int64,
+) (cosmostypes.BondStatus, error) {
+	info, err := svc.GetValidator(httpclient.AtHeight(ctx, height), validator)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "version does not exist"):
+			return 0, errors.Wrapf(ErrHeightPruned, "%d", height)
+		case httpclient.IsNotFound(err) || strings.Contains(err.Error(), "validator does not exist"):
+			return cosmostypes.Unbonded, nil
+		}
+		return 0, errors.Wrapf(err, "fetch validator %s at %d", validator.String(), height)
+	}
+	return info.Status, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"time"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+type ValidatorStatus string
+
+const (
+	ValidatorStatusBonded    ValidatorStatus = "bonded"
+	ValidatorStatusUnbonding ValidatorStatus = "unbonding"
+	ValidatorStatusUnbonded  ValidatorStatus = "unbonded"
+)
+
+type DelegationBalance struct {
+	DelegatorAddress cosmostypes.AccAddress `json:"delegator_address"`
+	ValidatorAddress cosmostypes.ValAddress `json:"validator_address"`
+	Shares           cosmostypes.Dec        `json:"shares"`
+	Balance          cosmostypes.Coin       `json:"balance"`
+}
+
+type PendingOperationType string
+
+const (
+	PendingOperationUnbonding    PendingOperationType = "unbonding"
+	PendingOperationRedelegation PendingOperationType = "redelegation"
+)
+
+type PendingOperation struct {
+	Type                 PendingOperationType   `json:"type"`
+	Balance              cosmostypes.Int        `json:"balance"`
+	SourceValidator      cosmostypes.ValAddress `json:"source_validator"`
+	DestinationValidator cosmostypes.ValAddress `json:"destination_validator,omitempty"`
+	CreationHeight       int64                  `json:"creation_height"`
+	CompletionTime       time.Time              `json:"completion_time"`
+}
+
+type CommissionChange struct {
+	Height         int64           `json:"height"`
+	TxHash         string          `json:"txhash"`
+	Timestamp      time.Time       `json:"timestamp"`
+	CommissionRate cosmostypes.Dec `json:"commission_rate"`
+}
+
+type StatusChange struct {
+	// Height is the first height at status To.
+	Height int64                  `json:"height"`
+	From   cosmostypes.BondStatus `json:"from"`
+	To     cosmostypes.BondStatus `json:"to"`
+}
@@ -0,0 +1,483 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	terraapp "github.com/terra-project/core/app"
+	terraauth "github.com/terra-project/core/x/auth"
+	"github.com/tj/assert"
+)
+
+const mockStakingParams = `{"height":"100","result":{
+	"unbonding_time":"1814400000000000",
+	"max_validators":100,
+	"max_entries":7,
+	"historical_entries":0,
+	"bond_denom":"uluna"
+}}`
+
+func mockValidator(operator cosmostypes.ValAddress, status int, jailed bool, tokens, shares string) string {
+	consPubKey := cosmostypes.MustBech32ifyPubKey(cosmostypes.Bech32PubKeyTypeConsPub, ed25519.GenPrivKey().PubKey())
+	return fmt.Sprintf(`{
+		"operator_address":"%s",
+		"consensus_pubkey":"%s",
+		"jailed":%t,
+		"status":%d,
+		"tokens":"%s",
+		"delegator_shares":"%s",
+		"description":{"moniker":"validator","identity":"","website":"","security_contact":"","details":""},
+		"unbonding_height":"0",
+		"unbonding_time":"1970-01-01T00:00:00Z",
+		"commission":{
+			"commission_rates":{"rate":"0.100000000000000000","max_rate":"0.200000000000000000","max_change_rate":"0.010000000000000000"},
+			"update_time":"2021-01-01T00:00:00Z"
+		},
+		"min_self_delegation":"1"
+	}`, operator.String(), consPubKey, jailed, status, tokens, shares)
+}
+
+func mockAddress(b byte) cosmostypes.AccAddress {
+	addr := make(cosmostypes.AccAddress, 20)
+	for i := range addr {
+		addr[i] = b
+	}
+	return addr
+}
+
+func TestGetDelegationBalances(t *testing.T) {
+	ctx := context.Background()
+
+	delegator := mockAddress(1)
+	healthy := cosmostypes.ValAddress(mockAddress(2))
+	slashed := cosmostypes.ValAddress(mockAddress(3))
+
+	client, closer := newMockClient(map[string]string{
+		"/staking/parameters": mockStakingParams,
+		"/staking/delegators/" + delegator.String() + "/delegations": fmt.Sprintf(`{"height":"100","result":[
+			{"delegator_address":"%[1]s","validator_address":"%[2]s","shares":"1000.000000000000000000","balance":{"denom":"uluna","amount":"1000"}},
+			{"delegator_address":"%[1]s","validator_address":"%[3]s","shares":"1000.000000000000000000","balance":{"denom":"uluna","amount":"1000"}}
+		]}`, delegator.String(), healthy.String(), slashed.String()),
+		"/staking/validators/" + healthy.String(): `{"height":"100","result":` + mockValidator(healthy, 2, false, "20000", "20000.000000000000000000") + `}`,
+		"/staking/validators/" + slashed.String(): `{"height":"100","result":` + mockValidator(slashed, 2, false, "9000", "10000.000000000000000000") + `}`,
+	})
+	defer closer()
+
+	balances, err := NewStakingService(client).GetDelegationBalances(ctx, delegator)
+	assert.NoError(t, err)
+	assert.Len(t, balances, 2)
+	assert.Equal(t, healthy, balances[0].ValidatorAddress)
+	assert.Equal(t, "1000uluna", balances[0].Balance.String())
+	assert.Equal(t, slashed, balances[1].ValidatorAddress)
+	assert.Equal(t, "900uluna", balances[1].Balance.String())
+}
+
+func TestGetTotalDelegatedTokens(t *testing.T) {
+	delegator := mockAddress(1)
+	healthy := cosmostypes.ValAddress(mockAddress(2))
+	slashed := cosmostypes.ValAddress(mockAddress(3))
+
+	client, closer := newMockClient(map[string]string{
+		"/staking/delegators/" + delegator.String() + "/delegations": fmt.Sprintf(`{"height":"100","result":[
+			{"delegator_address":"%[1]s","validator_address":"%[2]s","shares":"1000.000000000000000000","balance":{"denom":"uluna","amount":"1000"}},
+			{"delegator_address":"%[1]s","validator_address":"%[3]s","shares":"333.000000000000000000","balance":{"denom":"uluna","amount":"299"}}
+		]}`, delegator.String(), healthy.String(), slashed.String()),
+		"/staking/validators/" + healthy.String(): `{"height":"100","result":` + mockValidator(healthy, 2, false, "20000", "20000.000000000000000000") + `}`,
+		"/staking/validators/" + slashed.String(): `{"height":"100","result":` + mockValidator(slashed, 2, false, "9000", "10000.000000000000000000") + `}`,
+	})
+	defer closer()
+
+	// 1000 + 333 * 0.9 = 1299.7, truncated once over the sum
+	total, err := NewStakingService(client).GetTotalDelegatedTokens(context.Background(), delegator)
+	assert.NoError(t, err)
+	assert.Equal(t, "1299", total.String())
+}
+
+func TestGetCommissionHistory(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	operator := cosmostypes.ValAddress(mockAddress(4))
+	other := cosmostypes.ValAddress(mockAddress(5))
+
+	editTx := func(validator cosmostypes.ValAddress, rate *cosmostypes.Dec) terraauth.StdTx {
+		return terraauth.NewStdTx(
+			[]cosmostypes.Msg{stakingtypes.NewMsgEditValidator(validator, stakingtypes.Description{}, rate, nil)},
+			terraauth.StdFee{Gas: 200000},
+			nil,
+			"",
+		)
+	}
+	rate := func(s string) *cosmostypes.Dec {
+		d := cosmostypes.MustNewDecFromStr(s)
+		return &d
+	}
+
+	resp, err := cdc.MarshalJSON(QueryTxResponse{
+		TotalCount: cosmostypes.NewInt(3),
+		Count:      cosmostypes.NewInt(3),
+		PageNumber: cosmostypes.NewInt(1),
+		PageTotal:  cosmostypes.NewInt(1),
+		Limit:      cosmostypes.NewInt(100),
+		Txs: []cosmostypes.TxResponse{
+			{Height: 200, TxHash: "B", Timestamp: "2021-02-01T00:00:00Z", Tx: editTx(operator, rate("0.08"))},
+			{Height: 100, TxHash: "A", Timestamp: "2021-01-01T00:00:00Z", Tx: editTx(operator, rate("0.05"))},
+			{Height: 150, TxHash: "C", Timestamp: "2021-01-15T00:00:00Z", Tx: editTx(operator, nil)},
+		},
+	})
+	assert.NoError(t, err)
+
+	client, closer := newMockClient(map[string]string{"/txs": string(resp)})
+	defer closer()
+	svc := NewStakingService(client)
+
+	history, err := svc.GetCommissionHistory(context.Background(), operator)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, int64(100), history[0].Height)
+	assert.Equal(t, "0.050000000000000000", history[0].CommissionRate.String())
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), history[0].Timestamp)
+	assert.Equal(t, int64(200), history[1].Height)
+	assert.Equal(t, "0.080000000000000000", history[1].CommissionRate.String())
+
+	history, err = svc.GetCommissionHistory(context.Background(), other)
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestGetValidatorCounts(t *testing.T) {
+	validatorsByStatus := map[string]string{
+		"bonded": `[` + mockValidator(cosmostypes.ValAddress(mockAddress(1)), 2, false, "1000", "1000") + `,` +
+			mockValidator(cosmostypes.ValAddress(mockAddress(2)), 2, false, "1000", "1000") + `]`,
+		"unbonding": `[]`,
+		"unbonded":  `[` + mockValidator(cosmostypes.ValAddress(mockAddress(3)), 0, true, "1000", "1000") + `]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/staking/parameters" {
+			_, _ = w.Write([]byte(mockStakingParams))
+			return
+		}
+		_, _ = w.Write([]byte(`{"height":"100","result":` + validatorsByStatus[r.URL.Query().Get("status")] + `}`))
+	}))
+	defer server.Close()
+
+	active, total, max, err := NewStakingService(httpclient.New(nil, server.URL)).GetValidatorCounts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, active)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, 100, max)
+}
+
+func TestGetValidatorUnbondingDelegations(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	validator := cosmostypes.ValAddress(mockAddress(1))
+
+	unbondings := make([]stakingtypes.UnbondingDelegation, unbondingDelegationsPageLimit+3)
+	for i := range unbondings {
+		unbondings[i] = stakingtypes.NewUnbondingDelegation(
+			mockAddress(byte(i+2)), validator, 100, time.Unix(1600000000, 0).UTC(), cosmostypes.NewInt(int64(i+1)),
+		)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/staking/validators/"+validator.String()+"/unbonding_delegations", r.URL.Path)
+
+		page := unbondings[:unbondingDelegationsPageLimit]
+		if r.URL.Query().Get("page") == "2" {
+			page = unbondings[unbondingDelegationsPageLimit:]
+		}
+		bz, err := cdc.MarshalJSON(struct {
+			Height string                             `json:"height"`
+			Result []stakingtypes.UnbondingDelegation `json:"result"`
+		}{Height: "100", Result: page})
+		assert.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(bz)
+	}))
+	defer server.Close()
+
+	result, err := NewStakingService(httpclient.New(nil, server.URL)).
+		GetValidatorUnbondingDelegations(context.Background(), validator)
+	assert.NoError(t, err)
+	assert.Len(t, result, len(unbondings))
+	for i, unbonding := range result {
+		assert.Equal(t, unbondings[i].DelegatorAddress, unbonding.DelegatorAddress)
+		assert.Equal(t, int64(i+1), unbonding.Entries[0].Balance.Int64())
+	}
+}
+
+func TestGetValidatorUnbondingDelegationsIgnoredPaging(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	validator := cosmostypes.ValAddress(mockAddress(1))
+
+	unbondings := make([]stakingtypes.UnbondingDelegation, unbondingDelegationsPageLimit+3)
+	for i := range unbondings {
+		unbondings[i] = stakingtypes.NewUnbondingDelegation(
+			mockAddress(byte(i+2)), validator, 100, time.Unix(1600000000, 0).UTC(), cosmostypes.NewInt(int64(i+1)),
+		)
+	}
+
+	// old LCDs answer every page with the whole list
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		bz, err := cdc.MarshalJSON(struct {
+			Height string                             `json:"height"`
+			Result []stakingtypes.UnbondingDelegation `json:"result"`
+		}{Height: "100", Result: unbondings})
+		assert.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(bz)
+	}))
+	defer server.Close()
+
+	result, err := NewStakingService(httpclient.New(nil, server.URL)).
+		GetValidatorUnbondingDelegations(context.Background(), validator)
+	assert.NoError(t, err)
+	assert.Len(t, result, len(unbondings))
+	assert.Equal(t, 2, requests)
+}
+
+func TestCheckUndelegate(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	delegator := mockAddress(1)
+	full, spare := cosmostypes.ValAddress(mockAddress(2)), cosmostypes.ValAddress(mockAddress(3))
+
+	completion := time.Unix(1600000000, 0).UTC()
+	unbondings := []stakingtypes.UnbondingDelegation{
+		stakingtypes.NewUnbondingDelegation(delegator, full, 100, completion, cosmostypes.NewInt(1)),
+		stakingtypes.NewUnbondingDelegation(delegator, spare, 100, completion, cosmostypes.NewInt(1)),
+	}
+	for i := 1; i < 7; i++ {
+		unbondings[0].AddEntry(int64(100+i), completion, cosmostypes.NewInt(1))
+	}
+	unbondingsJSON, err := cdc.MarshalJSON(struct {
+		Height string                             `json:"height"`
+		Result []stakingtypes.UnbondingDelegation `json:"result"`
+	}{Height: "100", Result: unbondings})
+	assert.NoError(t, err)
+
+	client, closer := newMockClient(map[string]string{
+		"/staking/parameters": mockStakingParams,
+		"/staking/delegators/" + delegator.String() + "/unbonding_delegations": string(unbondingsJSON),
+	})
+	defer closer()
+
+	svc := NewStakingService(client)
+	assert.True(t, errors.Is(svc.CheckUndelegate(context.Background(), delegator, full), ErrMaxEntriesExceeded))
+	assert.NoError(t, svc.CheckUndelegate(context.Background(), delegator, spare))
+}
+
+func TestCheckRedelegate(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	delegator := mockAddress(1)
+	src, full, spare := cosmostypes.ValAddress(mockAddress(2)), cosmostypes.ValAddress(mockAddress(3)), cosmostypes.ValAddress(mockAddress(4))
+
+	completion := time.Unix(1600000000, 0).UTC()
+	var entries []stakingtypes.RedelegationEntryResponse
+	for i := 0; i < 7; i++ {
+		entries = append(entries, stakingtypes.NewRedelegationEntryResponse(
+			int64(100+i), completion, cosmostypes.NewDec(1), cosmostypes.NewInt(1), cosmostypes.NewInt(1),
+		))
+	}
+	redelegationsJSON, err := cdc.MarshalJSON(struct {
+		Height string                             `json:"height"`
+		Result stakingtypes.RedelegationResponses `json:"result"`
+	}{Height: "100", Result: stakingtypes.RedelegationResponses{
+		stakingtypes.NewRedelegationResponse(delegator, src, full, entries),
+		stakingtypes.NewRedelegationResponse(delegator, src, spare, entries[:6]),
+	}})
+	assert.NoError(t, err)
+
+	client, closer := newMockClient(map[string]string{
+		"/staking/parameters":    mockStakingParams,
+		"/staking/redelegations": string(redelegationsJSON),
+	})
+	defer closer()
+
+	// max_entries is 7, counted per source and destination pair
+	svc := NewStakingService(client)
+	assert.True(t, errors.Is(svc.CheckRedelegate(context.Background(), delegator, src, full), ErrMaxEntriesExceeded))
+	assert.NoError(t, svc.CheckRedelegate(context.Background(), delegator, src, spare))
+	assert.NoError(t, svc.CheckRedelegate(context.Background(), delegator, full, src))
+}
+
+func TestGetPendingOperations(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	delegator := mockAddress(1)
+	src, dst := cosmostypes.ValAddress(mockAddress(2)), cosmostypes.ValAddress(mockAddress(3))
+
+	day := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	unbonding := stakingtypes.NewUnbondingDelegation(delegator, src, 100, day.Add(48*time.Hour), cosmostypes.NewInt(10))
+	unbonding.AddEntry(104, day.Add(96*time.Hour), cosmostypes.NewInt(40))
+	unbondingsJSON, err := cdc.MarshalJSON(struct {
+		Height string                             `json:"height"`
+		Result []stakingtypes.UnbondingDelegation `json:"result"`
+	}{Height: "100", Result: []stakingtypes.UnbondingDelegation{unbonding}})
+	assert.NoError(t, err)
+
+	redelegationsJSON, err := cdc.MarshalJSON(struct {
+		Height string                             `json:"height"`
+		Result stakingtypes.RedelegationResponses `json:"result"`
+	}{Height: "100", Result: stakingtypes.RedelegationResponses{
+		stakingtypes.NewRedelegationResponse(delegator, src, dst, []stakingtypes.RedelegationEntryResponse{
+			stakingtypes.NewRedelegationEntryResponse(
+				102, day.Add(72*time.Hour), cosmostypes.NewDec(30), cosmostypes.NewInt(30), cosmostypes.NewInt(30),
+			),
+			stakingtypes.NewRedelegationEntryResponse(
+				101, day.Add(24*time.Hour), cosmostypes.NewDec(20), cosmostypes.NewInt(20), cosmostypes.NewInt(20),
+			),
+		}),
+	}})
+	assert.NoError(t, err)
+
+	client, closer := newMockClient(map[string]string{
+		"/staking/delegators/" + delegator.String() + "/unbonding_delegations": string(unbondingsJSON),
+		"/staking/redelegations": string(redelegationsJSON),
+	})
+	defer closer()
+
+	ops, err := NewStakingService(client).GetPendingOperations(context.Background(), delegator)
+	assert.NoError(t, err)
+	assert.Len(t, ops, 4)
+
+	// merged across both sources, soonest completion first
+	var heights []int64
+	for _, op := range ops {
+		heights = append(heights, op.CreationHeight)
+	}
+	assert.Equal(t, []int64{101, 100, 102, 104}, heights)
+	assert.Equal(t, PendingOperation{
+		Type:                 PendingOperationRedelegation,
+		Balance:              cosmostypes.NewInt(20),
+		SourceValidator:      src,
+		DestinationValidator: dst,
+		CreationHeight:       101,
+		CompletionTime:       day.Add(24 * time.Hour),
+	}, ops[0])
+	assert.Equal(t, PendingOperationUnbonding, ops[1].Type)
+	assert.Equal(t, cosmostypes.NewInt(10), ops[1].Balance)
+	assert.True(t, ops[1].DestinationValidator.Empty())
+	assert.Equal(t, PendingOperationRedelegation, ops[2].Type)
+	assert.Equal(t, PendingOperationUnbonding, ops[3].Type)
+}
+
+func TestGetValidatorForAccount(t *testing.T) {
+	ctx := context.Background()
+
+	operator := mockAddress(6)
+	delegator := mockAddress(7)
+	valoper := cosmostypes.ValAddress(operator)
+
+	client, closer := newMockClient(map[string]string{
+		"/staking/validators/" + valoper.String(): `{"height":"100","result":` + mockValidator(valoper, 2, false, "1000", "1000.000000000000000000") + `}`,
+	})
+	defer closer()
+
+	svc := NewStakingService(client)
+
+	validator, err := svc.GetValidatorForAccount(ctx, operator)
+	assert.NoError(t, err)
+	assert.NotNil(t, validator)
+	assert.Equal(t, valoper, validator.OperatorAddress)
+
+	validator, err = svc.GetValidatorForAccount(ctx, delegator)
+	assert.NoError(t, err)
+	assert.Nil(t, validator)
+}
+
+func TestGetValidatorNetAPR(t *testing.T) {
+	valoper := cosmostypes.ValAddress(mockAddress(8))
+
+	client, closer := newMockClient(map[string]string{
+		"/staking/validators/" + valoper.String(): `{"height":"100","result":` + mockValidator(valoper, 2, false, "1000", "1000.000000000000000000") + `}`,
+		"/minting/inflation":                      `{"height":"100","result":"0.070000000000000000"}`,
+		"/distribution/parameters": `{"height":"100","result":{
+			"community_tax":"0.020000000000000000",
+			"base_proposer_reward":"0.010000000000000000",
+			"bonus_proposer_reward":"0.040000000000000000",
+			"withdraw_addr_enabled":true
+		}}`,
+		"/staking/parameters": mockStakingParams,
+		"/staking/pool":       `{"height":"100","result":{"not_bonded_tokens":"100","bonded_tokens":"500"}}`,
+		"/supply/total/uluna": `{"height":"100","result":"1000"}`,
+	})
+	defer closer()
+
+	// 0.07 * (1 - 0.02) / 0.5 bonded = 0.1372 gross, less the 10% commission
+	apr, err := NewStakingService(client).GetValidatorNetAPR(context.Background(), valoper)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.123480000000000000", apr.String())
+
+	assert.Equal(t, "0.137200000000000000", NetAPR(cosmostypes.MustNewDecFromStr("0.1372"), cosmostypes.ZeroDec()).String())
+}
+
+func TestGetValidatorStatusChanges(t *testing.T) {
+	ctx := context.Background()
+	changing := cosmostypes.ValAddress(mockAddress(10))
+	steady := cosmostypes.ValAddress(mockAddress(11))
+
+	// changing is created bonded at 100, starts unbonding at 150 and is unbonded from 180
+	statusAt := func(validator cosmostypes.ValAddress, height int64) (int, bool) {
+		switch {
+		case validator.Equals(steady):
+			return 2, true
+		case height < 100:
+			return 0, false
+		case height < 150:
+			return 2, true
+		case height < 180:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var height int64
+		_, err := fmt.Sscan(r.URL.Query().Get("height"), &height)
+		assert.NoError(t, err)
+
+		for _, validator := range []cosmostypes.ValAddress{changing, steady} {
+			if r.URL.Path != "/staking/validators/"+validator.String() {
+				continue
+			}
+			status, ok := statusAt(validator, height)
+			if !ok {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error":"validator does not exist"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"height":"` + r.URL.Query().Get("height") + `","result":` +
+				mockValidator(validator, status, false, "1000", "1000.000000000000000000") + `}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	svc := NewStakingService(httpclient.New(terraapp.MakeCodec(), server.URL))
+
+	changes, err := svc.GetValidatorStatusChanges(ctx, changing, []int64{50, 200, 300})
+	assert.NoError(t, err)
+	assert.Equal(t, []StatusChange{
+		{Height: 100, From: cosmostypes.Unbonded, To: cosmostypes.Bonded},
+		{Height: 150, From: cosmostypes.Bonded, To: cosmostypes.Unbonding},
+		{Height: 180, From: cosmostypes.Unbonding, To: cosmostypes.Unbonded},
+	}, changes)
+
+	changes, err = svc.GetValidatorStatusChanges(ctx, steady, []int64{50, 200, 300})
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
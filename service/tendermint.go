@@ -9,6 +9,7 @@ import (
 
 	cosmosrpc "github.com/cosmos/cosmos-sdk/client/rpc"
 	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
 	"github.com/tendermint/tendermint/p2p"
 	tdmttypes "github.com/tendermint/tendermint/types"
@@ -19,6 +20,7 @@ type TendermintService interface {
 	GetNodeInfo(ctx context.Context) (p2p.DefaultNodeInfo, error)
 	GetSyncStatus(ctx context.Context) (cosmosrpc.SyncingResponse, error)
 	GetBlockByHeight(ctx context.Context, height *uint64) (tdmttypes.BlockID, *tdmttypes.Block, error)
+	IterateBlocks(ctx context.Context, fromHeight, toHeight int64, fn func(BlockResponse) error) error
 }
 
 type tendermintService struct {
@@ -81,3 +83,66 @@ func (svc tendermintService) GetBlockByHeight(ctx context.Context, height *uint6
 	}
 	return body.BlockID, &body.Block, nil
 }
+
+// IterateBlocks fetches blocks in [fromHeight, toHeight] one at a time, so only a single block is held in memory.
+// It stops with ctx's error once ctx is done.
+func (svc tendermintService) IterateBlocks(
+	ctx context.Context,
+	fromHeight, toHeight int64,
+	fn func(BlockResponse) error,
+) error {
+	if fromHeight < 1 {
+		return errors.Errorf("invalid from height %d", fromHeight)
+	}
+	for height := fromHeight; height <= toHeight; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		h := uint64(height)
+		blockID, block, err := svc.GetBlockByHeight(ctx, &h)
+		if err != nil {
+			return errors.Wrapf(err, "fetch block %d", height)
+		}
+		if err := fn(BlockResponse{BlockID: blockID, Block: block}); err != nil {
+			return errors.Wrapf(err, "handle block %d", height)
+		}
+		// height++ would wrap around past math.MaxInt64
+		if height == toHeight {
+			break
+		}
+	}
+	return nil
+}
+
+// BlockProposer returns the consensus address of the validator that proposed block.
+func BlockProposer(block *tdmttypes.Block) cosmostypes.ConsAddress {
+	return cosmostypes.ConsAddress(block.ProposerAddress)
+}
+
+// CommitSignatures returns how each validator in the set voted in the last commit of block,
+// in validator set order. The last commit is for the previous block, height - 1.
+func CommitSignatures(block *tdmttypes.Block) []CommitSignature {
+	if block.LastCommit == nil {
+		return nil
+	}
+
+	signatures := make([]CommitSignature, len(block.LastCommit.Signatures))
+	for i, sig := range block.LastCommit.Signatures {
+		var status CommitStatus
+		switch sig.BlockIDFlag {
+		case tdmttypes.BlockIDFlagCommit:
+			status = CommitSigned
+		case tdmttypes.BlockIDFlagNil:
+			status = CommitNil
+		default:
+			status = CommitAbsent
+		}
+		signatures[i] = CommitSignature{
+			ValidatorAddress: cosmostypes.ConsAddress(sig.ValidatorAddress),
+			Status:           status,
+			Timestamp:        sig.Timestamp,
+		}
+	}
+	return signatures
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"time"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	tdmttypes "github.com/tendermint/tendermint/types"
+)
+
+type BlockResponse struct {
+	BlockID tdmttypes.BlockID `json:"block_id"`
+	Block   *tdmttypes.Block  `json:"block"`
+}
+
+type CommitStatus int
+
+const (
+	// CommitAbsent means no vote of the validator made it into the commit.
+	CommitAbsent CommitStatus = iota
+	// CommitSigned means the validator voted for the block.
+	CommitSigned
+	// CommitNil means the validator voted nil, i.e. for no block.
+	CommitNil
+)
+
+type CommitSignature struct {
+	ValidatorAddress cosmostypes.ConsAddress `json:"validator_address"`
+	Status           CommitStatus            `json:"status"`
+	Timestamp        time.Time               `json:"timestamp"`
+}
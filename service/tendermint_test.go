@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tj/assert"
+)
+
+func TestIterateBlocks(t *testing.T) {
+	routes := map[string]string{}
+	for height := 5; height <= 7; height++ {
+		routes[fmt.Sprintf("/blocks/%d", height)] = fmt.Sprintf(`{"block_id":{},"block":{"header":{"height":"%d"}}}`, height)
+	}
+	client, closer := newMockClient(routes)
+	defer closer()
+	svc := NewTendermintService(client)
+
+	var heights []int64
+	err := svc.IterateBlocks(context.Background(), 5, 7, func(resp BlockResponse) error {
+		heights = append(heights, resp.Block.Height)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{5, 6, 7}, heights)
+
+	// canceled from the callback, the next block isn't fetched
+	ctx, cancel := context.WithCancel(context.Background())
+	heights = nil
+	err = svc.IterateBlocks(ctx, 5, 7, func(resp BlockResponse) error {
+		heights = append(heights, resp.Block.Height)
+		cancel()
+		return nil
+	})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, []int64{5}, heights)
+
+	err = svc.IterateBlocks(context.Background(), 0, 7, func(BlockResponse) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestCommitSignatures(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/blocks/100": `{"block_id":{},"block":{
+			"header":{"height":"100","time":"2021-01-01T00:00:06Z","proposer_address":"0101010101010101010101010101010101010101"},
+			"last_commit":{"height":"99","round":"0","block_id":{},"signatures":[
+				{"block_id_flag":2,"validator_address":"0101010101010101010101010101010101010101","timestamp":"2021-01-01T00:00:05Z","signature":"c2ln"},
+				{"block_id_flag":1,"validator_address":"","timestamp":"0001-01-01T00:00:00Z","signature":null},
+				{"block_id_flag":3,"validator_address":"0303030303030303030303030303030303030303","timestamp":"2021-01-01T00:00:05Z","signature":"c2ln"}
+			]}
+		}}`,
+	})
+	defer closer()
+
+	height := uint64(100)
+	_, block, err := NewTendermintService(client).GetBlockByHeight(context.Background(), &height)
+	assert.NoError(t, err)
+	assert.Equal(t, cosmostypes.ConsAddress(mockAddress(1)), BlockProposer(block))
+
+	signatures := CommitSignatures(block)
+	assert.Len(t, signatures, 3)
+	assert.Equal(t, CommitSigned, signatures[0].Status)
+	assert.Equal(t, cosmostypes.ConsAddress(mockAddress(1)), signatures[0].ValidatorAddress)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 5, 0, time.UTC), signatures[0].Timestamp)
+	assert.Equal(t, CommitAbsent, signatures[1].Status)
+	assert.True(t, signatures[1].ValidatorAddress.Empty())
+	assert.Equal(t, CommitNil, signatures[2].Status)
+	assert.Equal(t, cosmostypes.ConsAddress(mockAddress(3)), signatures[2].ValidatorAddress)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"time"
@@ -10,8 +11,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/rest"
 
 	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/rpcclient"
 	"github.com/cawabunga/terra.go/types"
 
+	"github.com/airbloc/logger"
 	"github.com/cosmos/cosmos-sdk/codec"
 	cosmostypes "github.com/cosmos/cosmos-sdk/types"
 	cosmosauthrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
@@ -24,11 +27,29 @@ import (
 type TransactionService interface {
 	GetTxByHash(ctx context.Context, txHash string) (cosmostypes.TxResponse, error)
 	QueryTx(ctx context.Context, req QueryTxRequest) (QueryTxResponse, error)
+	QueryFailedTxs(
+		ctx context.Context,
+		sender cosmostypes.AccAddress,
+		page, limit int64,
+	) (QueryTxResponse, error)
+	QueryTxByMemo(
+		ctx context.Context,
+		recipient cosmostypes.AccAddress,
+		memo string,
+		page, limit int64,
+	) (QueryTxResponse, error)
 	BroadcastTx(
 		ctx context.Context,
 		tx terraauth.StdTx,
 		mode types.BroadcastMode,
 	) (cosmostypes.TxResponse, error)
+	BroadcastTxDefault(ctx context.Context, tx terraauth.StdTx) (cosmostypes.TxResponse, error)
+	BroadcastTxAndWait(
+		ctx context.Context,
+		tx terraauth.StdTx,
+		wait types.WaitMode,
+	) (cosmostypes.TxResponse, error)
+	WaitForTx(ctx context.Context, txHash string, confirmations uint64) (cosmostypes.TxResponse, error)
 	EstimateFee(
 		ctx context.Context,
 		from string,
@@ -36,15 +57,53 @@ type TransactionService interface {
 		gasAdjustment string,
 		gasPrices cosmostypes.DecCoins,
 	) (terraauth.StdFee, error)
+	EstimateFeeFull(
+		ctx context.Context,
+		from string,
+		msg terraauth.StdSignMsg,
+		gasAdjustment string,
+		gasPrices cosmostypes.DecCoins,
+	) (EstimateFeeResponse, error)
 }
 
 type transactionService struct {
 	codec  *codec.Codec
 	client httpclient.Client
+	logger logger.Logger
+
+	auth            AuthService
+	tendermint      TendermintService
+	pollInterval    time.Duration
+	defaultMode     types.BroadcastMode
+	signerCheck     bool
+	gasPrices       GasPriceSource
+	gasPricesFormat GasPricesFormat
+	rpc             rpcclient.Client
+	txEncoder       TxEncoder
+
+	fallbackGas *uint64
+	feeCache    *feeCache
 }
 
-func NewTransactionService(client httpclient.Client) TransactionService {
-	return transactionService{codec: client.Codec(), client: client}
+func NewTransactionService(client httpclient.Client, opts ...TransactionOption) TransactionService {
+	svc := transactionService{
+		codec:  client.Codec(),
+		client: client,
+		logger: logger.New("service/transaction"),
+
+		auth:         NewAuthService(client),
+		tendermint:   NewTendermintService(client),
+		pollInterval: DefaultPollInterval,
+		defaultMode:  DefaultBroadcastMode,
+	}
+	for _, opt := range opts {
+		opt(&svc)
+	}
+	if !svc.defaultMode.Valid() {
+		svc.logger.Warn("invalid default broadcast mode {}. fallback to {}", svc.defaultMode, DefaultBroadcastMode)
+		svc.defaultMode = DefaultBroadcastMode
+	}
+	return svc
 }
 
 func (svc transactionService) GetTxByHash(
@@ -59,11 +118,41 @@ func (svc transactionService) GetTxByHash(
 
 	var body cosmostypes.TxResponse
 	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		if svc.rpc != nil && httpclient.IsNotFound(err) {
+			return svc.getTxByHashRPC(ctx, txHash)
+		}
 		return cosmostypes.TxResponse{}, errors.Wrap(err, "request json")
 	}
 	return body, nil
 }
 
+func (svc transactionService) getTxByHashRPC(
+	ctx context.Context,
+	txHash string,
+) (cosmostypes.TxResponse, error) {
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrap(err, "decode tx hash")
+	}
+
+	res, err := svc.rpc.Tx(ctx, hash)
+	if err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrap(err, "fetch tx from rpc")
+	}
+
+	var tx cosmostypes.Tx
+	if err := svc.codec.UnmarshalBinaryLengthPrefixed(res.Tx, &tx); err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrap(err, "unmarshal tx")
+	}
+
+	height := uint64(res.Height)
+	_, block, err := svc.tendermint.GetBlockByHeight(ctx, &height)
+	if err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrapf(err, "fetch block %d", height)
+	}
+	return cosmostypes.NewResponseResultTx(&res, tx, block.Time.Format(time.RFC3339)), nil
+}
+
 func (svc transactionService) QueryTx(ctx context.Context, req QueryTxRequest) (QueryTxResponse, error) {
 	var payload = httpclient.RequestPayload{
 		Context: ctx,
@@ -90,11 +179,232 @@ func (svc transactionService) QueryTx(ctx context.Context, req QueryTxRequest) (
 	return body, nil
 }
 
+// QueryFailedTxs searches a page of sender's txs and keeps those that failed.
+// The LCD can't filter by code, so a page may hold fewer than limit txs, or none,
+// while later pages still have failures; Count is the number kept, the rest describe the full search.
+func (svc transactionService) QueryFailedTxs(
+	ctx context.Context,
+	sender cosmostypes.AccAddress,
+	page, limit int64,
+) (QueryTxResponse, error) {
+	resp, err := svc.QueryTx(ctx, QueryTxRequest{
+		Page:  &page,
+		Limit: &limit,
+		Query: types.Q{"message.sender": accAddress(svc.client, sender)},
+	})
+	if err != nil {
+		return QueryTxResponse{}, errors.Wrapf(err, "search txs of %s", sender.String())
+	}
+
+	failed := make([]cosmostypes.TxResponse, 0)
+	for _, tx := range resp.Txs {
+		if tx.Code != abcitypes.CodeTypeOK {
+			failed = append(failed, tx)
+		}
+	}
+	resp.Txs = failed
+	resp.Count = cosmostypes.NewInt(int64(len(failed)))
+	return resp, nil
+}
+
+// QueryTxByMemo searches a page of txs transferring to recipient and keeps those whose memo is exactly memo.
+// Memos aren't indexed, so every incoming tx is downloaded and matched here; reconciling against
+// a busy address means walking all of its pages, so narrow the search window where possible.
+// Like QueryFailedTxs, Count is the number kept while the rest describe the full search.
+func (svc transactionService) QueryTxByMemo(
+	ctx context.Context,
+	recipient cosmostypes.AccAddress,
+	memo string,
+	page, limit int64,
+) (QueryTxResponse, error) {
+	resp, err := svc.QueryTx(ctx, QueryTxRequest{
+		Page:  &page,
+		Limit: &limit,
+		Query: types.Q{"transfer.recipient": accAddress(svc.client, recipient)},
+	})
+	if err != nil {
+		return QueryTxResponse{}, errors.Wrapf(err, "search txs to %s", recipient.String())
+	}
+
+	matched := make([]cosmostypes.TxResponse, 0)
+	for _, txResp := range resp.Txs {
+		tx, err := DecodedTx(txResp)
+		if err != nil {
+			return QueryTxResponse{}, errors.Wrapf(err, "decode tx %s", txResp.TxHash)
+		}
+		if tx.Memo == memo {
+			matched = append(matched, txResp)
+		}
+	}
+	resp.Txs = matched
+	resp.Count = cosmostypes.NewInt(int64(len(matched)))
+	return resp, nil
+}
+
 func (svc transactionService) BroadcastTx(
 	ctx context.Context,
 	tx terraauth.StdTx,
 	mode types.BroadcastMode,
 ) (cosmostypes.TxResponse, error) {
+	if mode == "" {
+		mode = svc.defaultMode
+	}
+	if !mode.Valid() {
+		return cosmostypes.TxResponse{}, errors.Errorf("invalid broadcast mode %q", mode)
+	}
+	body, err := svc.broadcast(ctx, tx, mode)
+	if err != nil {
+		return body, err
+	}
+	time.Sleep(1 * time.Second) // wait for lcd
+	return svc.fillGas(ctx, body), nil
+}
+
+// fillGas copies GasWanted and GasUsed from the indexed tx when a committed broadcast response
+// came back without them. The tx is already broadcast, so a failed lookup leaves resp as it is
+// rather than failing the broadcast.
+func (svc transactionService) fillGas(ctx context.Context, resp cosmostypes.TxResponse) cosmostypes.TxResponse {
+	if resp.Height == 0 || resp.GasWanted != 0 || resp.GasUsed != 0 {
+		return resp
+	}
+	indexed, err := svc.GetTxByHash(ctx, resp.TxHash)
+	if err != nil {
+		return resp
+	}
+	resp.GasWanted, resp.GasUsed = indexed.GasWanted, indexed.GasUsed
+	return resp
+}
+
+// BroadcastTxDefault broadcasts tx with the mode set by WithDefaultBroadcastMode, DefaultBroadcastMode if none.
+func (svc transactionService) BroadcastTxDefault(
+	ctx context.Context,
+	tx terraauth.StdTx,
+) (cosmostypes.TxResponse, error) {
+	return svc.BroadcastTx(ctx, tx, svc.defaultMode)
+}
+
+// BroadcastTxAndWait broadcasts tx and waits as the given mode says.
+func (svc transactionService) BroadcastTxAndWait(
+	ctx context.Context,
+	tx terraauth.StdTx,
+	wait types.WaitMode,
+) (cosmostypes.TxResponse, error) {
+	resp, err := svc.broadcast(ctx, tx, wait.BroadcastMode())
+	if err != nil {
+		return resp, err
+	}
+
+	switch wait.Kind {
+	case types.WaitKindCommitted:
+		return svc.waitForTx(ctx, resp.TxHash, 0, wait.TimeoutHeight)
+	case types.WaitKindConfirmed:
+		return svc.waitForTx(ctx, resp.TxHash, wait.Confirmations, wait.TimeoutHeight)
+	default:
+		return resp, nil
+	}
+}
+
+// WaitForTx polls until txHash is included in a block and confirmations more blocks are on top of it.
+func (svc transactionService) WaitForTx(
+	ctx context.Context,
+	txHash string,
+	confirmations uint64,
+) (cosmostypes.TxResponse, error) {
+	return svc.waitForTx(ctx, txHash, confirmations, 0)
+}
+
+// waitForTx is WaitForTx that returns ErrWaitTimedOut once the chain is past timeoutHeight
+// without the tx, if timeoutHeight is set. The node doesn't know about timeoutHeight, so the tx
+// may still be in the mempool and get included later.
+func (svc transactionService) waitForTx(
+	ctx context.Context,
+	txHash string,
+	confirmations, timeoutHeight uint64,
+) (cosmostypes.TxResponse, error) {
+	var resp cosmostypes.TxResponse
+	for {
+		// the height is read before the lookup, so a tx included at the timeout height
+		// right after the block fetch is still found.
+		var expired bool
+		if timeoutHeight != 0 {
+			_, block, err := svc.tendermint.GetBlockByHeight(ctx, nil)
+			if err != nil {
+				return cosmostypes.TxResponse{}, errors.Wrap(err, "fetch latest block")
+			}
+			expired = uint64(block.Height) > timeoutHeight
+		}
+
+		found, err := svc.GetTxByHash(ctx, txHash)
+		if err == nil {
+			resp = found
+			break
+		}
+		if !httpclient.IsNotFound(err) {
+			return cosmostypes.TxResponse{}, errors.Wrap(err, "fetch tx")
+		}
+		if expired {
+			return cosmostypes.TxResponse{}, errors.Wrapf(
+				ErrWaitTimedOut,
+				"tx %s not found past timeout height %d",
+				txHash, timeoutHeight,
+			)
+		}
+		if err := svc.sleep(ctx); err != nil {
+			return cosmostypes.TxResponse{}, err
+		}
+	}
+	if resp.Code != abcitypes.CodeTypeOK {
+		return resp, errors.New(resp.RawLog)
+	}
+
+	for confirmations > 0 {
+		_, block, err := svc.tendermint.GetBlockByHeight(ctx, nil)
+		if err != nil {
+			return resp, errors.Wrap(err, "fetch latest block")
+		}
+		if block.Height >= resp.Height+int64(confirmations) {
+			break
+		}
+		if err := svc.sleep(ctx); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+func (svc transactionService) sleep(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(svc.pollInterval):
+		return nil
+	}
+}
+
+func (svc transactionService) broadcast(
+	ctx context.Context,
+	tx terraauth.StdTx,
+	mode types.BroadcastMode,
+) (cosmostypes.TxResponse, error) {
+	if svc.gasPrices != nil {
+		minimums, err := svc.gasPrices.GetGasPrices(ctx)
+		if err != nil {
+			return cosmostypes.TxResponse{}, errors.Wrap(err, "fetch minimum gas prices")
+		}
+		if err := checkGasPrice(tx.Fee, minimums); err != nil {
+			return cosmostypes.TxResponse{}, err
+		}
+	}
+	if svc.signerCheck {
+		if err := svc.checkSigners(ctx, tx); err != nil {
+			return cosmostypes.TxResponse{}, err
+		}
+	}
+
+	if svc.txEncoder != nil {
+		return svc.broadcastV1Beta1(ctx, tx, mode)
+	}
+
 	var req = cosmosauthrest.BroadcastReq{
 		Tx:   tx,
 		Mode: string(mode),
@@ -110,13 +420,14 @@ func (svc transactionService) BroadcastTx(
 		Method:  http.MethodPost,
 		Path:    "/txs",
 		Body:    bytes.NewReader(rawPayloadBody),
+
+		Idempotent: true,
 	}
 
 	var body cosmostypes.TxResponse
 	if err := svc.client.RequestJSON(payload, &body); err != nil {
 		return cosmostypes.TxResponse{}, errors.Wrap(err, "request json")
 	}
-	time.Sleep(1 * time.Second) // wait for lcd
 
 	if body.Code != abcitypes.CodeTypeOK {
 		return body, errors.New(body.RawLog)
@@ -124,6 +435,64 @@ func (svc transactionService) BroadcastTx(
 	return body, nil
 }
 
+// staleSequenceLookback is how many sequences back checkSigners looks to tell a stale signature.
+const staleSequenceLookback = 16
+
+// checkSigners verifies the signatures of tx against the current account number and sequence
+// of its signers. Any mismatch other than a recently used sequence, e.g. a wrong chain id,
+// is reported as ErrWrongAccountNumber.
+func (svc transactionService) checkSigners(ctx context.Context, tx terraauth.StdTx) error {
+	nodeInfo, err := svc.tendermint.GetNodeInfo(ctx)
+	if err != nil {
+		return errors.Wrap(err, "fetch node info")
+	}
+
+	signers := tx.GetSigners()
+	if len(tx.Signatures) != len(signers) {
+		return errors.Errorf("tx has %d signatures for %d signers", len(tx.Signatures), len(signers))
+	}
+	for i, signer := range signers {
+		account, err := svc.auth.GetAccountInfo(ctx, signer)
+		if err != nil {
+			return errors.Wrapf(err, "fetch account of %s", signer.String())
+		}
+
+		sig := tx.Signatures[i]
+		verify := func(sequence uint64) bool {
+			signMsg := terraauth.StdSignMsg{
+				ChainID:       nodeInfo.Network,
+				AccountNumber: account.GetAccountNumber(),
+				Sequence:      sequence,
+				Fee:           tx.Fee,
+				Msgs:          tx.Msgs,
+				Memo:          tx.Memo,
+			}
+			return sig.PubKey != nil && sig.PubKey.VerifyBytes(signMsg.Bytes(), sig.Signature)
+		}
+
+		current := account.GetSequence()
+		if verify(current) {
+			continue
+		}
+		for sequence := current; sequence > 0 && current-sequence < staleSequenceLookback; {
+			sequence--
+			if verify(sequence) {
+				return errors.Wrapf(
+					ErrStaleSequence,
+					"%s signed with sequence %d, current is %d",
+					signer.String(), sequence, current,
+				)
+			}
+		}
+		return errors.Wrapf(
+			ErrWrongAccountNumber,
+			"signature of %s doesn't match account number %d and sequence %d on %s",
+			signer.String(), account.GetAccountNumber(), current, nodeInfo.Network,
+		)
+	}
+	return nil
+}
+
 func (svc transactionService) EstimateFee(
 	ctx context.Context,
 	from string,
@@ -131,27 +500,79 @@ func (svc transactionService) EstimateFee(
 	gasAdjustment string,
 	gasPrices cosmostypes.DecCoins,
 ) (terraauth.StdFee, error) {
-	var req = struct {
-		BaseReq rest.BaseReq      `json:"base_req"`
-		Msgs    []cosmostypes.Msg `json:"msgs"`
-	}{
-		BaseReq: rest.BaseReq{
-			From:          from,
-			Memo:          msg.Memo,
-			ChainID:       msg.ChainID,
-			AccountNumber: msg.AccountNumber,
-			Sequence:      msg.Sequence,
-			GasPrices:     gasPrices,
-			Gas:           "auto",
-			GasAdjustment: gasAdjustment,
-			Simulate:      false,
-		},
-		Msgs: msg.Msgs,
+	var cacheKey string
+	if svc.feeCache != nil && !isFeeCacheBypassed(ctx) {
+		cacheKey = feeCacheKey(msg.Msgs, gasAdjustment, gasPrices)
+		if fee, ok := svc.feeCache.get(cacheKey); ok {
+			return fee, nil
+		}
 	}
 
-	rawPayloadBody, err := svc.codec.MarshalJSON(req)
+	resp, fallback, err := svc.estimateFee(ctx, from, msg, gasAdjustment, gasPrices)
+	if err != nil {
+		return terraauth.StdFee{}, err
+	}
+	if cacheKey != "" && !fallback {
+		svc.feeCache.put(cacheKey, resp.Fee)
+	}
+	return resp.Fee, nil
+}
+
+// EstimateFeeFull is EstimateFee with the height and raw gas estimate the LCD answered with.
+// It never reads from the fee cache.
+func (svc transactionService) EstimateFeeFull(
+	ctx context.Context,
+	from string,
+	msg terraauth.StdSignMsg,
+	gasAdjustment string,
+	gasPrices cosmostypes.DecCoins,
+) (EstimateFeeResponse, error) {
+	resp, _, err := svc.estimateFee(ctx, from, msg, gasAdjustment, gasPrices)
+	return resp, err
+}
+
+func (svc transactionService) estimateFee(
+	ctx context.Context,
+	from string,
+	msg terraauth.StdSignMsg,
+	gasAdjustment string,
+	gasPrices cosmostypes.DecCoins,
+) (EstimateFeeResponse, bool, error) {
+	var baseReq = rest.BaseReq{
+		From:          from,
+		Memo:          msg.Memo,
+		ChainID:       msg.ChainID,
+		AccountNumber: msg.AccountNumber,
+		Sequence:      msg.Sequence,
+		GasPrices:     gasPrices,
+		Gas:           "auto",
+		GasAdjustment: gasAdjustment,
+		Simulate:      false,
+	}
+
+	var (
+		rawPayloadBody []byte
+		err            error
+	)
+	if svc.gasPricesFormat == GasPricesString {
+		rawPayloadBody, err = svc.codec.MarshalJSON(struct {
+			BaseReq stringGasPricesBaseReq `json:"base_req"`
+			Msgs    []cosmostypes.Msg      `json:"msgs"`
+		}{
+			BaseReq: newStringGasPricesBaseReq(baseReq),
+			Msgs:    msg.Msgs,
+		})
+	} else {
+		rawPayloadBody, err = svc.codec.MarshalJSON(struct {
+			BaseReq rest.BaseReq      `json:"base_req"`
+			Msgs    []cosmostypes.Msg `json:"msgs"`
+		}{
+			BaseReq: baseReq,
+			Msgs:    msg.Msgs,
+		})
+	}
 	if err != nil {
-		return terraauth.StdFee{}, errors.Wrap(err, "marshal request body")
+		return EstimateFeeResponse{}, false, errors.Wrap(err, "marshal request body")
 	}
 
 	var payload = httpclient.RequestPayload{
@@ -162,13 +583,45 @@ func (svc transactionService) EstimateFee(
 	}
 
 	var body struct {
-		Height string `json:"height"`
+		Height int64 `json:"height"`
 		Result struct {
-			Fee terraauth.StdFee `json:"fee"`
+			Fee         terraauth.StdFee `json:"fee"`
+			GasEstimate uint64           `json:"gas_estimate"`
 		} `json:"result"`
 	}
 	if err := svc.client.RequestJSON(payload, &body); err != nil {
-		return terraauth.StdFee{}, errors.Wrap(err, "request json")
+		if svc.fallbackGas != nil && httpclient.IsStatus(err, http.StatusNotFound, http.StatusNotImplemented) {
+			svc.logger.Info("estimate_fee is unavailable. fallback to default gas {}", *svc.fallbackGas)
+			return EstimateFeeResponse{
+				Fee:         fallbackFee(*svc.fallbackGas, gasPrices),
+				GasEstimate: *svc.fallbackGas,
+			}, true, nil
+		}
+		return EstimateFeeResponse{}, false, errors.Wrap(err, "request json")
+	}
+
+	resp := EstimateFeeResponse{
+		Height:      body.Height,
+		Fee:         body.Result.Fee,
+		GasEstimate: body.Result.GasEstimate,
+	}
+	if resp.GasEstimate == 0 {
+		// older LCDs only return the adjusted gas in the fee
+		resp.GasEstimate = resp.Fee.Gas
+	}
+	return resp, false, nil
+}
+
+func fallbackFee(gas uint64, gasPrices cosmostypes.DecCoins) terraauth.StdFee {
+	amount := make(cosmostypes.Coins, 0, len(gasPrices))
+	for _, price := range gasPrices {
+		amount = append(amount, cosmostypes.NewCoin(
+			price.Denom,
+			price.Amount.MulInt64(int64(gas)).Ceil().RoundInt(),
+		))
+	}
+	return terraauth.StdFee{
+		Amount: cosmostypes.NewCoins(amount...),
+		Gas:    gas,
 	}
-	return body.Result.Fee, nil
 }
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	terrawasm "github.com/terra-project/core/x/wasm"
+)
+
+type bypassFeeCacheKey struct{}
+
+// BypassFeeCache makes EstimateFee skip the fee cache for calls made with the returned context.
+func BypassFeeCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassFeeCacheKey{}, true)
+}
+
+func isFeeCacheBypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(bypassFeeCacheKey{}).(bool)
+	return bypassed
+}
+
+type feeCacheEntry struct {
+	fee       terraauth.StdFee
+	expiresAt time.Time
+}
+
+type feeCache struct {
+	ttl        time.Duration
+	multiplier float64
+
+	mutex   sync.Mutex
+	entries map[string]feeCacheEntry
+}
+
+func newFeeCache(ttl time.Duration, multiplier float64) *feeCache {
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	return &feeCache{
+		ttl:        ttl,
+		multiplier: multiplier,
+		entries:    make(map[string]feeCacheEntry),
+	}
+}
+
+// feeCacheKey identifies a transaction shape: the number, type and order of its messages, the size
+// of multisends and the contract and message of wasm calls, plus the pricing inputs.
+func feeCacheKey(msgs []cosmostypes.Msg, gasAdjustment string, gasPrices cosmostypes.DecCoins) string {
+	parts := make([]string, 0, len(msgs)+3)
+	parts = append(parts, strconv.Itoa(len(msgs)))
+	for _, msg := range msgs {
+		parts = append(parts, msg.Route()+"/"+msg.Type()+msgShape(msg))
+	}
+	parts = append(parts, gasAdjustment, gasPrices.String())
+	return strings.Join(parts, "|")
+}
+
+// msgShape is what the gas of msg depends on beyond its type. Other types cost about the same whatever
+// their amounts and addresses.
+func msgShape(msg cosmostypes.Msg) string {
+	switch msg := msg.(type) {
+	case terrabank.MsgMultiSend:
+		return fmt.Sprintf("/%d/%d", len(msg.Inputs), len(msg.Outputs))
+	case terrawasm.MsgExecuteContract:
+		return "/" + msg.Contract.String() + "/" + contentHash(msg.ExecuteMsg)
+	case types.MsgExecuteContract:
+		return "/" + msg.Contract.String() + "/" + contentHash(msg.ExecuteMsg)
+	case terrawasm.MsgInstantiateContract:
+		return fmt.Sprintf("/%d/%s", msg.CodeID, contentHash(msg.InitMsg))
+	case terrawasm.MsgStoreCode:
+		return "/" + contentHash(msg.WASMByteCode)
+	default:
+		return ""
+	}
+}
+
+func contentHash(bz []byte) string {
+	sum := sha256.Sum256(bz)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *feeCache) get(key string) (terraauth.StdFee, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return terraauth.StdFee{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return terraauth.StdFee{}, false
+	}
+	return c.applyMultiplier(entry.fee), true
+}
+
+func (c *feeCache) put(key string, fee terraauth.StdFee) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[key] = feeCacheEntry{
+		fee:       fee,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *feeCache) applyMultiplier(fee terraauth.StdFee) terraauth.StdFee {
+	if c.multiplier == 1 {
+		return fee
+	}
+
+	multiplier := cosmostypes.MustNewDecFromStr(strconv.FormatFloat(c.multiplier, 'f', -1, 64))
+	amount := make(cosmostypes.Coins, 0, len(fee.Amount))
+	for _, coin := range fee.Amount {
+		amount = append(amount, cosmostypes.NewCoin(
+			coin.Denom,
+			multiplier.MulInt(coin.Amount).Ceil().RoundInt(),
+		))
+	}
+	return terraauth.StdFee{
+		Amount: amount,
+		Gas:    uint64(multiplier.MulInt64(int64(fee.Gas)).Ceil().RoundInt64()),
+	}
+}
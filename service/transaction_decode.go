@@ -0,0 +1,38 @@
+package service
+
+import (
+	"encoding/base64"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// DecodedTx returns the tx embedded in resp, as decoded along with QueryTx or GetTxByHash.
+func DecodedTx(resp cosmostypes.TxResponse) (terraauth.StdTx, error) {
+	switch tx := resp.Tx.(type) {
+	case terraauth.StdTx:
+		return tx, nil
+	case *terraauth.StdTx:
+		return *tx, nil
+	case nil:
+		return terraauth.StdTx{}, errors.Wrapf(ErrUnexpectedTxType, "tx %s has no embedded tx", resp.TxHash)
+	default:
+		return terraauth.StdTx{}, errors.Wrapf(ErrUnexpectedTxType, "tx %s is %T", resp.TxHash, tx)
+	}
+}
+
+// DecodeTxBytes decodes a base64 amino-encoded tx, the form blocks and the RPC carry txs in.
+func DecodeTxBytes(cdc *codec.Codec, encoded string) (terraauth.StdTx, error) {
+	bz, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return terraauth.StdTx{}, errors.Wrap(err, "decode base64")
+	}
+
+	var tx cosmostypes.Tx
+	if err := cdc.UnmarshalBinaryLengthPrefixed(bz, &tx); err != nil {
+		return terraauth.StdTx{}, errors.Wrap(err, "unmarshal tx")
+	}
+	return DecodedTx(cosmostypes.TxResponse{Tx: tx})
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	terraapp "github.com/terra-project/core/app"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestDecodedTx(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+
+	tx := terraauth.NewStdTx(
+		[]cosmostypes.Msg{terrabank.MsgSend{
+			FromAddress: mockAddress(1),
+			ToAddress:   mockAddress(2),
+			Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+		}},
+		terraauth.StdFee{
+			Amount: cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 30000)),
+			Gas:    200000,
+		},
+		nil,
+		"decode me",
+	)
+	resp, err := cdc.MarshalJSON(QueryTxResponse{
+		TotalCount: cosmostypes.NewInt(1),
+		Count:      cosmostypes.NewInt(1),
+		PageNumber: cosmostypes.NewInt(1),
+		PageTotal:  cosmostypes.NewInt(1),
+		Limit:      cosmostypes.NewInt(30),
+		Txs:        []cosmostypes.TxResponse{{Height: 100, TxHash: "ABCD", Tx: tx}},
+	})
+	assert.NoError(t, err)
+
+	client, closer := newMockClient(map[string]string{"/txs": string(resp)})
+	defer closer()
+
+	result, err := NewTransactionService(client).QueryTx(context.Background(), QueryTxRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Txs, 1)
+
+	decoded, err := DecodedTx(result.Txs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "decode me", decoded.Memo)
+	assert.Equal(t, uint64(200000), decoded.Fee.Gas)
+	assert.Equal(t, tx.Msgs, decoded.Msgs)
+
+	_, err = DecodedTx(cosmostypes.TxResponse{TxHash: "EMPTY"})
+	assert.Error(t, err)
+
+	raw, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	assert.NoError(t, err)
+	decoded, err = DecodeTxBytes(cdc, base64.StdEncoding.EncodeToString(raw))
+	assert.NoError(t, err)
+	assert.Equal(t, "decode me", decoded.Memo)
+}
+
+func TestQueryTxByMemo(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	recipient := mockAddress(2)
+
+	send := func(memo string) terraauth.StdTx {
+		return terraauth.NewStdTx(
+			[]cosmostypes.Msg{terrabank.MsgSend{
+				FromAddress: mockAddress(1),
+				ToAddress:   recipient,
+				Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+			}},
+			terraauth.StdFee{Gas: 200000},
+			nil,
+			memo,
+		)
+	}
+	resp, err := cdc.MarshalJSON(QueryTxResponse{
+		TotalCount: cosmostypes.NewInt(3),
+		Count:      cosmostypes.NewInt(3),
+		PageNumber: cosmostypes.NewInt(1),
+		PageTotal:  cosmostypes.NewInt(1),
+		Limit:      cosmostypes.NewInt(30),
+		Txs: []cosmostypes.TxResponse{
+			{Height: 100, TxHash: "A", Tx: send("deposit-1001")},
+			{Height: 101, TxHash: "B", Tx: send("deposit-10012")},
+			{Height: 102, TxHash: "C", Tx: send("deposit-1001")},
+		},
+	})
+	assert.NoError(t, err)
+
+	client, closer := newMockClient(map[string]string{"/txs": string(resp)})
+	defer closer()
+
+	result, err := NewTransactionService(client).QueryTxByMemo(context.Background(), recipient, "deposit-1001", 1, 30)
+	assert.NoError(t, err)
+	assert.Len(t, result.Txs, 2)
+	assert.Equal(t, "A", result.Txs[0].TxHash)
+	assert.Equal(t, "C", result.Txs[1].TxHash)
+	assert.Equal(t, int64(2), result.Count.Int64())
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"encoding/json"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// FeeBreakdown is what a committed tx actually cost.
+type FeeBreakdown struct {
+	// Fee is the whole fee paid, Tax included.
+	Fee cosmostypes.Coins
+	// Tax is the stability tax charged on the sent amounts, reported in the msg logs.
+	Tax       cosmostypes.Coins
+	GasWanted int64
+	GasUsed   int64
+	// GasPrices is the part of Fee that paid for gas, per gas used.
+	GasPrices cosmostypes.DecCoins
+}
+
+// AnalyzeTxFee breaks down the fee of a tx as returned by GetTxByHash or QueryTx.
+func AnalyzeTxFee(resp cosmostypes.TxResponse) (FeeBreakdown, error) {
+	tx, err := DecodedTx(resp)
+	if err != nil {
+		return FeeBreakdown{}, err
+	}
+
+	var tax cosmostypes.Coins
+	for _, log := range resp.Logs {
+		var msgLog struct {
+			Tax string `json:"tax"`
+		}
+		// logs of msgs without tax aren't json
+		if err := json.Unmarshal([]byte(log.Log), &msgLog); err != nil || msgLog.Tax == "" {
+			continue
+		}
+		coins, err := cosmostypes.ParseCoins(msgLog.Tax)
+		if err != nil {
+			return FeeBreakdown{}, errors.Wrapf(err, "parse tax of msg #%d", log.MsgIndex)
+		}
+		tax = tax.Add(coins...)
+	}
+
+	gasFee, negative := tx.Fee.Amount.SafeSub(tax)
+	if negative {
+		return FeeBreakdown{}, errors.Errorf("tax %s exceeds fee %s", tax, tx.Fee.Amount)
+	}
+
+	var gasPrices cosmostypes.DecCoins
+	if resp.GasUsed > 0 {
+		gasPrices = cosmostypes.NewDecCoinsFromCoins(gasFee...).QuoDec(cosmostypes.NewDec(resp.GasUsed))
+	}
+
+	return FeeBreakdown{
+		Fee:       tx.Fee.Amount,
+		Tax:       tax,
+		GasWanted: resp.GasWanted,
+		GasUsed:   resp.GasUsed,
+		GasPrices: gasPrices,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	terraapp "github.com/terra-project/core/app"
+	"github.com/tj/assert"
+)
+
+func TestAnalyzeTxFee(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+
+	var resp cosmostypes.TxResponse
+	assert.NoError(t, cdc.UnmarshalJSON([]byte(`{
+		"height":"100",
+		"txhash":"ABCD",
+		"logs":[{"msg_index":0,"log":"{\"tax\":\"1000uusd\"}","events":[]}],
+		"gas_wanted":"200000",
+		"gas_used":"150000",
+		"tx":{"type":"core/StdTx","value":{
+			"msg":[{"type":"bank/MsgSend","value":{
+				"from_address":"`+mockAddress(1).String()+`",
+				"to_address":"`+mockAddress(2).String()+`",
+				"amount":[{"denom":"uusd","amount":"1000000"}]
+			}}],
+			"fee":{"amount":[{"denom":"uusd","amount":"31000"}],"gas":"200000"},
+			"signatures":null,
+			"memo":""
+		}}
+	}`), &resp))
+
+	breakdown, err := AnalyzeTxFee(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "31000uusd", breakdown.Fee.String())
+	assert.Equal(t, "1000uusd", breakdown.Tax.String())
+	assert.Equal(t, int64(200000), breakdown.GasWanted)
+	assert.Equal(t, int64(150000), breakdown.GasUsed)
+	assert.Equal(t, "0.200000000000000000uusd", breakdown.GasPrices.String())
+}
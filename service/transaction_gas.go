@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// GasPriceSource tells the lowest gas price per denom a tx is accepted with.
+// fcd.Client is one, reporting the lowest tier of the prices FCD recommends.
+type GasPriceSource interface {
+	GetGasPrices(ctx context.Context) (map[string]cosmostypes.Dec, error)
+}
+
+type staticGasPrices map[string]cosmostypes.Dec
+
+// StaticGasPrices is a GasPriceSource of fixed prices, e.g. the minimum-gas-prices a node is configured with.
+func StaticGasPrices(prices cosmostypes.DecCoins) GasPriceSource {
+	static := make(staticGasPrices, len(prices))
+	for _, price := range prices {
+		static[price.Denom] = price.Amount
+	}
+	return static
+}
+
+func (p staticGasPrices) GetGasPrices(context.Context) (map[string]cosmostypes.Dec, error) {
+	return p, nil
+}
+
+// GasEfficiency returns the share of the gas limit the tx actually used.
+//
+// Only DeliverTx results carry gas, i.e. responses of ModeBlock broadcasts, GetTxByHash, QueryTx
+// and BroadcastTxAndWait with WaitCommitted or WaitConfirmed. BroadcastTx fills in the gas of a
+// ModeBlock response the node left it out of from the indexed tx. ModeSync and ModeAsync broadcasts
+// return before the tx runs, so their GasWanted and GasUsed are zero and so is the efficiency.
+func GasEfficiency(resp cosmostypes.TxResponse) float64 {
+	if resp.GasWanted <= 0 {
+		return 0
+	}
+	return float64(resp.GasUsed) / float64(resp.GasWanted)
+}
+
+// checkGasPrice fails with ErrGasPriceTooLow unless fee pays at least the minimum price in one
+// of its denoms. Denoms without a minimum don't count.
+func checkGasPrice(fee terraauth.StdFee, minimums map[string]cosmostypes.Dec) error {
+	if len(minimums) == 0 || fee.Gas == 0 {
+		return nil
+	}
+
+	gas := cosmostypes.NewDecFromInt(cosmostypes.NewIntFromUint64(fee.Gas))
+	var required []string
+	for denom, price := range minimums {
+		if !price.IsPositive() {
+			return nil
+		}
+		need := price.Mul(gas).Ceil().TruncateInt()
+		if fee.Amount.AmountOf(denom).GTE(need) {
+			return nil
+		}
+		required = append(required, cosmostypes.NewCoin(denom, need).String())
+	}
+	sort.Strings(required)
+	return errors.Wrapf(
+		ErrGasPriceTooLow,
+		"fee %s for %d gas, need one of %s",
+		fee.Amount, fee.Gas, strings.Join(required, ", "),
+	)
+}
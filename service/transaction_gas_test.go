@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+	"github.com/tj/assert"
+)
+
+func TestGasEfficiency(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/txs/ABCD": `{"height":"100","txhash":"ABCD","code":0,"gas_wanted":"200000","gas_used":"150000"}`,
+	})
+	defer closer()
+
+	resp, err := NewTransactionService(client).GetTxByHash(context.Background(), "ABCD")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.75, GasEfficiency(resp))
+
+	resp.GasWanted, resp.GasUsed = 0, 0
+	assert.Equal(t, float64(0), GasEfficiency(resp))
+}
+
+func TestBroadcastTxFillsGas(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/txs":
+			// committed, but without the gas of the DeliverTx result
+			_, _ = w.Write([]byte(`{"height":"100","txhash":"ABCD","code":0}`))
+		case "/txs/ABCD":
+			_, _ = w.Write([]byte(`{"height":"100","txhash":"ABCD","code":0,"gas_wanted":"200000","gas_used":"150000"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	resp, err := NewTransactionService(httpclient.New(nil, server.URL)).
+		BroadcastTx(context.Background(), terraauth.StdTx{}, types.ModeBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200000), resp.GasWanted)
+	assert.Equal(t, int64(150000), resp.GasUsed)
+	assert.Equal(t, 0.75, GasEfficiency(resp))
+}
+
+func TestBroadcastTxGasPriceCheck(t *testing.T) {
+	var broadcasts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&broadcasts, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"0","txhash":"ABCD","code":0}`))
+	}))
+	defer server.Close()
+
+	minimums := StaticGasPrices(cosmostypes.DecCoins{
+		cosmostypes.NewDecCoinFromDec("uluna", cosmostypes.NewDecWithPrec(15, 2)),
+		cosmostypes.NewDecCoinFromDec("uusd", cosmostypes.NewDecWithPrec(15, 2)),
+	})
+	svc := NewTransactionService(httpclient.New(nil, server.URL), WithGasPriceCheck(minimums))
+
+	tx := func(fee cosmostypes.Coins) terraauth.StdTx {
+		return terraauth.NewStdTx(nil, terraauth.StdFee{Amount: fee, Gas: 200000}, nil, "")
+	}
+
+	_, err := svc.BroadcastTx(context.Background(), tx(cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 29999))), types.ModeSync)
+	assert.True(t, errors.Is(err, ErrGasPriceTooLow))
+	assert.Contains(t, err.Error(), "30000uluna, 30000uusd")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&broadcasts))
+
+	_, err = svc.BroadcastTx(context.Background(), tx(cosmostypes.NewCoins(
+		cosmostypes.NewInt64Coin("uluna", 1),
+		cosmostypes.NewInt64Coin("uusd", 30000),
+	)), types.ModeSync)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&broadcasts))
+}
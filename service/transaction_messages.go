@@ -0,0 +1,179 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/cawabunga/terra.go/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosdistr "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/pkg/errors"
+	terrabank "github.com/terra-project/core/x/bank"
+	terramarket "github.com/terra-project/core/x/market"
+	terrawasm "github.com/terra-project/core/x/wasm"
+)
+
+type MessageKind string
+
+const (
+	MessageSend            MessageKind = "send"
+	MessageMultiSend       MessageKind = "multi_send"
+	MessageSwap            MessageKind = "swap"
+	MessageDelegate        MessageKind = "delegate"
+	MessageUndelegate      MessageKind = "undelegate"
+	MessageRedelegate      MessageKind = "redelegate"
+	MessageWithdrawReward  MessageKind = "withdraw_reward"
+	MessageExecuteContract MessageKind = "execute_contract"
+	MessageVote            MessageKind = "vote"
+	// MessageOther is any msg without a dedicated kind, look at its Raw json.
+	MessageOther MessageKind = "other"
+)
+
+// MessageSummary describes a msg of any type in the same shape. Fields holds the msg's
+// addresses and parameters by name, e.g. "from", "to" and "validator", and Amount the coins it moves.
+type MessageSummary struct {
+	Index   int
+	Kind    MessageKind
+	Route   string
+	Type    string
+	Signers []cosmostypes.AccAddress
+	Fields  map[string]string
+	Amount  cosmostypes.Coins
+	Raw     json.RawMessage
+}
+
+// ClassifyMessages summarizes the msgs of a tx as returned by GetTxByHash or QueryTx.
+func ClassifyMessages(cdc *codec.Codec, resp cosmostypes.TxResponse) ([]MessageSummary, error) {
+	tx, err := DecodedTx(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]MessageSummary, len(tx.Msgs))
+	for index, msg := range tx.Msgs {
+		raw, err := cdc.MarshalJSON(msg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "marshal msg #%d", index)
+		}
+
+		summary := classifyMessage(msg)
+		summary.Index = index
+		summary.Route = msg.Route()
+		summary.Type = msg.Type()
+		summary.Signers = msg.GetSigners()
+		summary.Raw = raw
+		summaries[index] = summary
+	}
+	return summaries, nil
+}
+
+func classifyMessage(msg cosmostypes.Msg) MessageSummary {
+	switch msg := msg.(type) {
+	case terrabank.MsgSend:
+		return MessageSummary{
+			Kind:   MessageSend,
+			Fields: map[string]string{"from": msg.FromAddress.String(), "to": msg.ToAddress.String()},
+			Amount: msg.Amount,
+		}
+	case terrabank.MsgMultiSend:
+		var total cosmostypes.Coins
+		for _, input := range msg.Inputs {
+			total = total.Add(input.Coins...)
+		}
+		return MessageSummary{
+			Kind: MessageMultiSend,
+			Fields: map[string]string{
+				"inputs":  strconv.Itoa(len(msg.Inputs)),
+				"outputs": strconv.Itoa(len(msg.Outputs)),
+			},
+			Amount: total,
+		}
+	case terramarket.MsgSwap:
+		return MessageSummary{
+			Kind:   MessageSwap,
+			Fields: map[string]string{"trader": msg.Trader.String(), "ask_denom": msg.AskDenom},
+			Amount: cosmostypes.NewCoins(msg.OfferCoin),
+		}
+	case terramarket.MsgSwapSend:
+		return MessageSummary{
+			Kind: MessageSwap,
+			Fields: map[string]string{
+				"from":      msg.FromAddress.String(),
+				"to":        msg.ToAddress.String(),
+				"ask_denom": msg.AskDenom,
+			},
+			Amount: cosmostypes.NewCoins(msg.OfferCoin),
+		}
+	case stakingtypes.MsgDelegate:
+		return MessageSummary{
+			Kind: MessageDelegate,
+			Fields: map[string]string{
+				"delegator": msg.DelegatorAddress.String(),
+				"validator": msg.ValidatorAddress.String(),
+			},
+			Amount: cosmostypes.NewCoins(msg.Amount),
+		}
+	case stakingtypes.MsgUndelegate:
+		return MessageSummary{
+			Kind: MessageUndelegate,
+			Fields: map[string]string{
+				"delegator": msg.DelegatorAddress.String(),
+				"validator": msg.ValidatorAddress.String(),
+			},
+			Amount: cosmostypes.NewCoins(msg.Amount),
+		}
+	case stakingtypes.MsgBeginRedelegate:
+		return MessageSummary{
+			Kind: MessageRedelegate,
+			Fields: map[string]string{
+				"delegator":     msg.DelegatorAddress.String(),
+				"validator_src": msg.ValidatorSrcAddress.String(),
+				"validator_dst": msg.ValidatorDstAddress.String(),
+			},
+			Amount: cosmostypes.NewCoins(msg.Amount),
+		}
+	case cosmosdistr.MsgWithdrawDelegatorReward:
+		return MessageSummary{
+			Kind: MessageWithdrawReward,
+			Fields: map[string]string{
+				"delegator": msg.DelegatorAddress.String(),
+				"validator": msg.ValidatorAddress.String(),
+			},
+		}
+	case terrawasm.MsgExecuteContract:
+		return MessageSummary{
+			Kind: MessageExecuteContract,
+			Fields: map[string]string{
+				"sender":      msg.Sender.String(),
+				"contract":    msg.Contract.String(),
+				"execute_msg": string(msg.ExecuteMsg),
+			},
+			Amount: msg.Coins,
+		}
+	case types.MsgExecuteContract:
+		return MessageSummary{
+			Kind: MessageExecuteContract,
+			Fields: map[string]string{
+				"sender":      msg.Sender.String(),
+				"contract":    msg.Contract.String(),
+				"execute_msg": string(msg.ExecuteMsg),
+			},
+			Amount: msg.Coins,
+		}
+	case govtypes.MsgVote:
+		return MessageSummary{
+			Kind: MessageVote,
+			Fields: map[string]string{
+				"voter":       msg.Voter.String(),
+				"proposal_id": strconv.FormatUint(msg.ProposalID, 10),
+				"option":      msg.Option.String(),
+			},
+		}
+	default:
+		return MessageSummary{Kind: MessageOther, Fields: map[string]string{}}
+	}
+}
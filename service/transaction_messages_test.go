@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosdistr "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	terraapp "github.com/terra-project/core/app"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	terramarket "github.com/terra-project/core/x/market"
+	"github.com/tj/assert"
+)
+
+func TestClassifyMessages(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	sender, recipient := mockAddress(1), mockAddress(2)
+	validator := cosmostypes.ValAddress(mockAddress(3))
+	luna := cosmostypes.NewInt64Coin("uluna", 1000000)
+
+	tx := terraauth.NewStdTx(
+		[]cosmostypes.Msg{
+			terrabank.NewMsgSend(sender, recipient, cosmostypes.NewCoins(luna)),
+			stakingtypes.NewMsgDelegate(sender, validator, luna),
+			terramarket.NewMsgSwap(sender, luna, "uusd"),
+			cosmosdistr.NewMsgSetWithdrawAddress(sender, recipient),
+		},
+		terraauth.StdFee{Gas: 200000},
+		nil,
+		"",
+	)
+
+	summaries, err := ClassifyMessages(cdc, cosmostypes.TxResponse{TxHash: "ABCD", Tx: tx})
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 4)
+
+	assert.Equal(t, MessageSend, summaries[0].Kind)
+	assert.Equal(t, recipient.String(), summaries[0].Fields["to"])
+	assert.Equal(t, "1000000uluna", summaries[0].Amount.String())
+
+	assert.Equal(t, MessageDelegate, summaries[1].Kind)
+	assert.Equal(t, validator.String(), summaries[1].Fields["validator"])
+	assert.Equal(t, "1000000uluna", summaries[1].Amount.String())
+
+	assert.Equal(t, MessageSwap, summaries[2].Kind)
+	assert.Equal(t, "uusd", summaries[2].Fields["ask_denom"])
+	assert.Equal(t, "market", summaries[2].Route)
+
+	assert.Equal(t, MessageOther, summaries[3].Kind)
+	assert.Equal(t, 3, summaries[3].Index)
+	assert.Equal(t, []cosmostypes.AccAddress{sender}, summaries[3].Signers)
+	assert.Contains(t, string(summaries[3].Raw), `"type":"distribution/MsgModifyWithdrawAddress"`)
+	assert.Contains(t, string(summaries[3].Raw), recipient.String())
+}
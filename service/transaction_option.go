@@ -0,0 +1,98 @@
+package service
+
+import (
+	"time"
+
+	"github.com/cawabunga/terra.go/rpcclient"
+	"github.com/cawabunga/terra.go/types"
+)
+
+const (
+	DefaultPollInterval  = time.Second
+	DefaultBroadcastMode = types.ModeSync
+)
+
+type TransactionOption func(*transactionService)
+
+// GasPricesFormat is how gas prices are encoded in base_req of a request body.
+type GasPricesFormat int
+
+const (
+	// GasPricesArray encodes gas prices as a DecCoins array, e.g. [{"denom":"uluna","amount":"0.15"}].
+	GasPricesArray GasPricesFormat = iota
+	// GasPricesString encodes gas prices as a comma-separated string, e.g. "0.15uluna,0.1uusd".
+	GasPricesString
+)
+
+// WithPollInterval sets how often WaitForTx polls the LCD.
+func WithPollInterval(interval time.Duration) TransactionOption {
+	return func(svc *transactionService) {
+		svc.pollInterval = interval
+	}
+}
+
+// WithFeeFallback makes EstimateFee fall back to defaultGas × gas prices
+// when the LCD doesn't serve /txs/estimate_fee (404 or 501).
+func WithFeeFallback(defaultGas uint64) TransactionOption {
+	return func(svc *transactionService) {
+		svc.fallbackGas = &defaultGas
+	}
+}
+
+// WithFeeCache caches EstimateFee results per transaction shape for ttl.
+// Cached fees are scaled by multiplier on a hit to leave headroom for variance between calls.
+func WithFeeCache(ttl time.Duration, multiplier float64) TransactionOption {
+	return func(svc *transactionService) {
+		svc.feeCache = newFeeCache(ttl, multiplier)
+	}
+}
+
+// WithGasPricesFormat sets how EstimateFee encodes gas prices, for LCDs that don't take the array form.
+func WithGasPricesFormat(format GasPricesFormat) TransactionOption {
+	return func(svc *transactionService) {
+		svc.gasPricesFormat = format
+	}
+}
+
+// WithRPCFallback makes GetTxByHash look the tx up on the Tendermint RPC when the LCD answers 404,
+// since the LCD occasionally misses committed txs in its index.
+func WithRPCFallback(rpc rpcclient.Client) TransactionOption {
+	return func(svc *transactionService) {
+		svc.rpc = rpc
+	}
+}
+
+// WithSignerCheck makes broadcasts first verify each signature of the tx against the signer's
+// current account number and sequence, failing with ErrStaleSequence or ErrWrongAccountNumber
+// instead of sending a tx the node would reject. It costs a round trip per signer.
+func WithSignerCheck() TransactionOption {
+	return func(svc *transactionService) {
+		svc.signerCheck = true
+	}
+}
+
+// WithGasPriceCheck makes broadcasts first check the fee of the tx against the minimum gas prices
+// of source, failing with ErrGasPriceTooLow instead of sending a tx the node would reject for
+// insufficient fees. It costs a round trip unless source is StaticGasPrices.
+func WithGasPriceCheck(source GasPriceSource) TransactionOption {
+	return func(svc *transactionService) {
+		svc.gasPrices = source
+	}
+}
+
+// WithDefaultBroadcastMode sets the mode BroadcastTxDefault, and BroadcastTx given an empty mode,
+// broadcast with. NewTransactionService logs an unknown mode and uses DefaultBroadcastMode instead.
+func WithDefaultBroadcastMode(mode types.BroadcastMode) TransactionOption {
+	return func(svc *transactionService) {
+		svc.defaultMode = mode
+	}
+}
+
+// WithV1Beta1Broadcast makes broadcasts post to /cosmos/tx/v1beta1/txs, for nodes that dropped the
+// legacy /txs endpoint. The endpoint takes a protobuf TxRaw, which the amino codec here can't produce,
+// so encoder has to.
+func WithV1Beta1Broadcast(encoder TxEncoder) TransactionOption {
+	return func(svc *transactionService) {
+		svc.txEncoder = encoder
+	}
+}
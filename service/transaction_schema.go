@@ -4,6 +4,8 @@ import (
 	"github.com/cawabunga/terra.go/types"
 
 	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	terraauth "github.com/terra-project/core/x/auth"
 )
 
 type QueryTxRequest struct {
@@ -20,3 +22,57 @@ type QueryTxResponse struct {
 	Limit      cosmostypes.Int          `json:"limit"`
 	Txs        []cosmostypes.TxResponse `json:"txs"`
 }
+
+type EstimateFeeResponse struct {
+	Height      int64            `json:"height"`
+	Fee         terraauth.StdFee `json:"fee"`
+	GasEstimate uint64           `json:"gas_estimate"`
+}
+
+// stringGasPricesBaseReq is rest.BaseReq with gas prices in their comma-separated string form.
+type stringGasPricesBaseReq struct {
+	From          string            `json:"from"`
+	Memo          string            `json:"memo"`
+	ChainID       string            `json:"chain_id"`
+	AccountNumber uint64            `json:"account_number"`
+	Sequence      uint64            `json:"sequence"`
+	Fees          cosmostypes.Coins `json:"fees"`
+	GasPrices     string            `json:"gas_prices"`
+	Gas           string            `json:"gas"`
+	GasAdjustment string            `json:"gas_adjustment"`
+	Simulate      bool              `json:"simulate"`
+}
+
+func newStringGasPricesBaseReq(req rest.BaseReq) stringGasPricesBaseReq {
+	return stringGasPricesBaseReq{
+		From:          req.From,
+		Memo:          req.Memo,
+		ChainID:       req.ChainID,
+		AccountNumber: req.AccountNumber,
+		Sequence:      req.Sequence,
+		Fees:          req.Fees,
+		GasPrices:     req.GasPrices.String(),
+		Gas:           req.Gas,
+		GasAdjustment: req.GasAdjustment,
+		Simulate:      req.Simulate,
+	}
+}
+
+type v1beta1BroadcastReq struct {
+	TxBytes []byte `json:"tx_bytes"`
+	Mode    string `json:"mode"`
+}
+
+// v1beta1TxResponse is the grpc-gateway form of a TxResponse, with 64-bit integers as strings.
+type v1beta1TxResponse struct {
+	Height    int64  `json:"height,string"`
+	TxHash    string `json:"txhash"`
+	Codespace string `json:"codespace"`
+	Code      uint32 `json:"code"`
+	Data      string `json:"data"`
+	RawLog    string `json:"raw_log"`
+	Info      string `json:"info"`
+	GasWanted int64  `json:"gas_wanted,string"`
+	GasUsed   int64  `json:"gas_used,string"`
+	Timestamp string `json:"timestamp"`
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cawabunga/terra.go/rpcclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// wasmEventTypes are the events contracts emit their attributes in, each group of them
+// starting with the contract_address of the emitting contract.
+var wasmEventTypes = map[string]bool{"from_contract": true, "wasm": true}
+
+type SimulateTxResponse struct {
+	GasUsed uint64
+	Log     string
+	Events  cosmostypes.StringEvents
+	// WasmEvents holds the attributes contracts emitted, in the order they were emitted.
+	WasmEvents []WasmEvent
+}
+
+type WasmEvent struct {
+	Contract   string
+	Attributes map[string]string
+}
+
+// SimulateTx runs tx against the latest state on the Tendermint RPC without broadcasting it.
+// Unlike EstimateFee, it returns what the tx would emit, e.g. the attributes of contract calls.
+// A tx failing in simulation returns the error it would fail with.
+func SimulateTx(
+	ctx context.Context,
+	cdc *codec.Codec,
+	rpc rpcclient.Client,
+	tx terraauth.StdTx,
+) (SimulateTxResponse, error) {
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	if err != nil {
+		return SimulateTxResponse{}, errors.Wrap(err, "marshal tx")
+	}
+
+	value, _, err := rpc.ABCIQuery(ctx, "/app/simulate", txBytes)
+	if err != nil {
+		return SimulateTxResponse{}, errors.Wrap(err, "simulate tx")
+	}
+
+	var sim cosmostypes.SimulationResponse
+	if err := codec.Cdc.UnmarshalBinaryBare(value, &sim); err != nil {
+		return SimulateTxResponse{}, errors.Wrap(err, "unmarshal simulation response")
+	}
+
+	resp := SimulateTxResponse{GasUsed: sim.GasUsed}
+	if sim.Result != nil {
+		resp.Log = sim.Result.Log
+		resp.Events = cosmostypes.StringifyEvents(sim.Result.Events)
+		resp.WasmEvents = decodeWasmEvents(resp.Events)
+	}
+	return resp, nil
+}
+
+func decodeWasmEvents(events cosmostypes.StringEvents) []WasmEvent {
+	var wasmEvents []WasmEvent
+	for _, event := range events {
+		if !wasmEventTypes[event.Type] {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "contract_address" {
+				wasmEvents = append(wasmEvents, WasmEvent{Contract: attr.Value, Attributes: map[string]string{}})
+				continue
+			}
+			if len(wasmEvents) == 0 {
+				// attributes before any contract_address belong to no known contract
+				wasmEvents = append(wasmEvents, WasmEvent{Attributes: map[string]string{}})
+			}
+			wasmEvents[len(wasmEvents)-1].Attributes[attr.Key] = attr.Value
+		}
+	}
+	return wasmEvents
+}
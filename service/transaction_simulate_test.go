@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/rpcclient"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	terraapp "github.com/terra-project/core/app"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrawasm "github.com/terra-project/core/x/wasm"
+	"github.com/tj/assert"
+)
+
+func TestSimulateTxWasmEvents(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	sender, token, pair := mockAddress(1), mockAddress(2), mockAddress(3)
+
+	tx := terraauth.NewStdTx(
+		[]cosmostypes.Msg{terrawasm.NewMsgExecuteContract(
+			sender, pair, []byte(`{"swap":{}}`), nil,
+		)},
+		terraauth.StdFee{Gas: 500000},
+		nil,
+		"",
+	)
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	assert.NoError(t, err)
+
+	sim := cosmostypes.SimulationResponse{
+		GasInfo: cosmostypes.GasInfo{GasWanted: 500000, GasUsed: 312000},
+		Result: &cosmostypes.Result{Events: cosmostypes.Events{
+			cosmostypes.NewEvent("execute_contract",
+				cosmostypes.NewAttribute("sender", sender.String()),
+				cosmostypes.NewAttribute("contract_address", pair.String()),
+			),
+			cosmostypes.NewEvent("from_contract",
+				cosmostypes.NewAttribute("contract_address", pair.String()),
+				cosmostypes.NewAttribute("action", "swap"),
+				cosmostypes.NewAttribute("return_amount", "998"),
+				cosmostypes.NewAttribute("contract_address", token.String()),
+				cosmostypes.NewAttribute("action", "transfer"),
+				cosmostypes.NewAttribute("amount", "998"),
+			),
+		}.ToABCIEvents()},
+	}
+	value := codec.Cdc.MustMarshalBinaryBare(sim)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/abci_query", r.URL.Path)
+		assert.Equal(t, `"/app/simulate"`, r.URL.Query().Get("path"))
+		assert.Equal(t, fmt.Sprintf("0x%x", txBytes), r.URL.Query().Get("data"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"response":{
+			"code":0,"log":"","info":"","index":"0","key":null,
+			"value":"` + base64.StdEncoding.EncodeToString(value) + `",
+			"proof":null,"height":"100","codespace":""
+		}}}`))
+	}))
+	defer server.Close()
+
+	resp, err := SimulateTx(context.Background(), cdc, rpcclient.New(httpclient.New(cdc, server.URL)), tx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(312000), resp.GasUsed)
+	assert.Len(t, resp.Events, 2)
+	assert.Equal(t, []WasmEvent{
+		{Contract: pair.String(), Attributes: map[string]string{"action": "swap", "return_amount": "998"}},
+		{Contract: token.String(), Attributes: map[string]string{"action": "transfer", "amount": "998"}},
+	}, resp.WasmEvents)
+}
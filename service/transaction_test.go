@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/rpcclient"
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	cosmosauth "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraapp "github.com/terra-project/core/app"
+	terraauth "github.com/terra-project/core/x/auth"
+	terrabank "github.com/terra-project/core/x/bank"
+	"github.com/tj/assert"
+)
+
+func TestEstimateFeeFallback(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	gasPrices := cosmostypes.DecCoins{{
+		Denom:  "uluna",
+		Amount: cosmostypes.NewDecWithPrec(15, 2),
+	}}
+	signMsg := terraauth.StdSignMsg{ChainID: "bombay-12"}
+
+	svc := NewTransactionService(httpclient.New(nil, server.URL), WithFeeFallback(200000))
+	fee, err := svc.EstimateFee(ctx, "", signMsg, "1.2", gasPrices)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(200000), fee.Gas)
+	assert.Equal(t, "30000uluna", fee.Amount.String())
+
+	svc = NewTransactionService(httpclient.New(nil, server.URL))
+	_, err = svc.EstimateFee(ctx, "", signMsg, "1.2", gasPrices)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, httpclient.StatusCode(err))
+}
+
+func TestEstimateFeeGasPricesFormat(t *testing.T) {
+	ctx := context.Background()
+
+	var gasPrices json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			BaseReq struct {
+				GasPrices json.RawMessage `json:"gas_prices"`
+			} `json:"base_req"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		gasPrices = req.BaseReq.GasPrices
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"100","result":{"fee":{"amount":[{"denom":"uluna","amount":"3000"}],"gas":"20000"}}}`))
+	}))
+	defer server.Close()
+
+	prices := cosmostypes.DecCoins{
+		cosmostypes.NewDecCoinFromDec("uluna", cosmostypes.NewDecWithPrec(15, 2)),
+		cosmostypes.NewDecCoinFromDec("uusd", cosmostypes.NewDecWithPrec(1, 1)),
+	}
+	signMsg := terraauth.StdSignMsg{ChainID: "bombay-12"}
+
+	_, err := NewTransactionService(httpclient.New(nil, server.URL)).
+		EstimateFee(ctx, "", signMsg, "1.2", prices)
+	assert.NoError(t, err)
+	assert.Equal(t, byte('['), gasPrices[0])
+
+	_, err = NewTransactionService(httpclient.New(nil, server.URL), WithGasPricesFormat(GasPricesString)).
+		EstimateFee(ctx, "", signMsg, "1.2", prices)
+	assert.NoError(t, err)
+	assert.Equal(t, `"0.150000000000000000uluna,0.100000000000000000uusd"`, string(gasPrices))
+}
+
+func TestEstimateFeeCache(t *testing.T) {
+	ctx := context.Background()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"100","result":{"fee":{"amount":[{"denom":"uluna","amount":"3000"}],"gas":"20000"}}}`))
+	}))
+	defer server.Close()
+
+	addr, err := cosmostypes.AccAddressFromBech32("terra12avq876h9mn3wehchcezaafd4kdyjzer4u79kc")
+	assert.NoError(t, err)
+	signMsg := terraauth.StdSignMsg{
+		ChainID: "bombay-12",
+		Msgs: []cosmostypes.Msg{terrabank.MsgSend{
+			FromAddress: addr,
+			ToAddress:   addr,
+			Amount:      cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000)),
+		}},
+	}
+	gasPrices := cosmostypes.DecCoins{{Denom: "uluna", Amount: cosmostypes.NewDecWithPrec(15, 2)}}
+
+	svc := NewTransactionService(httpclient.New(nil, server.URL), WithFeeCache(time.Minute, 1.1))
+
+	fee, err := svc.EstimateFee(ctx, addr.String(), signMsg, "1.2", gasPrices)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20000), fee.Gas)
+
+	cached, err := svc.EstimateFee(ctx, addr.String(), signMsg, "1.2", gasPrices)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(22000), cached.Gas)
+	assert.Equal(t, "3300uluna", cached.Amount.String())
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	_, err = svc.EstimateFee(BypassFeeCache(ctx), addr.String(), signMsg, "1.2", gasPrices)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestFeeCacheKeyShape(t *testing.T) {
+	from, to := mockAddress(1), mockAddress(2)
+	coins := cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uusd", 1000000))
+	multiSend := func(outputs int) cosmostypes.Msg {
+		msg := terrabank.MsgMultiSend{Inputs: []terrabank.Input{terrabank.NewInput(from, coins)}}
+		for i := 0; i < outputs; i++ {
+			msg.Outputs = append(msg.Outputs, terrabank.NewOutput(to, coins))
+		}
+		return msg
+	}
+	execute := func(contract cosmostypes.AccAddress, msg string) cosmostypes.Msg {
+		return types.MsgExecuteContract{Sender: from, Contract: contract, ExecuteMsg: json.RawMessage(msg)}
+	}
+	gasPrices := cosmostypes.DecCoins{{Denom: "uluna", Amount: cosmostypes.NewDecWithPrec(15, 2)}}
+	key := func(msgs ...cosmostypes.Msg) string { return feeCacheKey(msgs, "1.2", gasPrices) }
+
+	assert.Equal(t, key(multiSend(2)), key(multiSend(2)))
+	assert.NotEqual(t, key(multiSend(2)), key(multiSend(200)))
+	assert.NotEqual(t, key(multiSend(1)), key(multiSend(1), multiSend(1)))
+	assert.Equal(t, key(execute(to, `{"claim":{}}`)), key(execute(to, `{"claim":{}}`)))
+	assert.NotEqual(t, key(execute(to, `{"claim":{}}`)), key(execute(mockAddress(3), `{"claim":{}}`)))
+	assert.NotEqual(t, key(execute(to, `{"claim":{}}`)), key(execute(to, `{"swap":{}}`)))
+}
+
+func TestBroadcastTxAndWait(t *testing.T) {
+	ctx := context.Background()
+
+	type counters struct {
+		mode   string
+		lookup int32
+		blocks int32
+	}
+	newServer := func(c *counters) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/txs":
+				var req struct {
+					Mode string `json:"mode"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				c.mode = req.Mode
+				_, _ = w.Write([]byte(`{"height":"0","txhash":"ABCD","code":0}`))
+			case "/txs/ABCD":
+				if atomic.AddInt32(&c.lookup, 1) == 1 {
+					http.NotFound(w, r)
+					return
+				}
+				_, _ = w.Write([]byte(`{"height":"10","txhash":"ABCD","code":0}`))
+			case "/blocks/latest":
+				height := 10 + atomic.AddInt32(&c.blocks, 1) - 1
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"block_id":{},"block":{"header":{"height":"%d"}}}`, height)))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+	}
+
+	cases := []struct {
+		wait          types.WaitMode
+		mode          string
+		height        int64
+		lookups       int32
+		blockRequests int32
+	}{
+		{wait: types.WaitNone, mode: "async", height: 0, lookups: 0, blockRequests: 0},
+		{wait: types.WaitCheckTx, mode: "sync", height: 0, lookups: 0, blockRequests: 0},
+		{wait: types.WaitCommitted, mode: "sync", height: 10, lookups: 2, blockRequests: 0},
+		{wait: types.WaitConfirmed(2), mode: "sync", height: 10, lookups: 2, blockRequests: 3},
+	}
+	for _, c := range cases {
+		var cnt counters
+		server := newServer(&cnt)
+
+		svc := NewTransactionService(httpclient.New(nil, server.URL), WithPollInterval(time.Millisecond))
+		resp, err := svc.BroadcastTxAndWait(ctx, terraauth.StdTx{}, c.wait)
+		server.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ABCD", resp.TxHash)
+		assert.Equal(t, c.height, resp.Height)
+		assert.Equal(t, c.mode, cnt.mode)
+		assert.Equal(t, c.lookups, cnt.lookup)
+		assert.Equal(t, c.blockRequests, cnt.blocks)
+	}
+}
+
+func TestBroadcastTxAndWaitTimeoutHeight(t *testing.T) {
+	var blocks int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/txs":
+			_, _ = w.Write([]byte(`{"height":"0","txhash":"ABCD","code":0}`))
+		case "/blocks/latest":
+			height := 10 + atomic.AddInt32(&blocks, 1) - 1
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"block_id":{},"block":{"header":{"height":"%d"}}}`, height)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewTransactionService(httpclient.New(nil, server.URL), WithPollInterval(time.Millisecond))
+	_, err := svc.BroadcastTxAndWait(context.Background(), terraauth.StdTx{}, types.WaitCommitted.WithTimeoutHeight(12))
+	assert.True(t, errors.Is(err, ErrWaitTimedOut))
+	// heights 10, 11 and 12 are still in time, 13 is past it
+	assert.Equal(t, int32(4), atomic.LoadInt32(&blocks))
+}
+
+func TestBroadcastTxSignerCheck(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	privKey := secp256k1.GenPrivKey()
+	addr := cosmostypes.AccAddress(privKey.PubKey().Address())
+
+	account := authtypes.NewBaseAccount(addr, nil, privKey.PubKey(), 7, 5)
+	accountJSON, err := cdc.MarshalJSON(struct {
+		Height string             `json:"height"`
+		Result cosmosauth.Account `json:"result"`
+	}{Height: "100", Result: account})
+	assert.NoError(t, err)
+
+	var broadcasts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/node_info":
+			_, _ = w.Write([]byte(`{"node_info":{"network":"bombay-12"}}`))
+		case "/auth/accounts/" + addr.String():
+			_, _ = w.Write(accountJSON)
+		case "/txs":
+			atomic.AddInt32(&broadcasts, 1)
+			_, _ = w.Write([]byte(`{"height":"0","txhash":"ABCD","code":0}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	signedTx := func(chainID string, accountNumber, sequence uint64) terraauth.StdTx {
+		msgs := []cosmostypes.Msg{terrabank.NewMsgSend(addr, mockAddress(2), cosmostypes.NewCoins(cosmostypes.NewInt64Coin("uluna", 1)))}
+		fee := terraauth.StdFee{Gas: 200000}
+		signMsg := terraauth.StdSignMsg{
+			ChainID:       chainID,
+			AccountNumber: accountNumber,
+			Sequence:      sequence,
+			Fee:           fee,
+			Msgs:          msgs,
+		}
+		sig, err := privKey.Sign(signMsg.Bytes())
+		assert.NoError(t, err)
+		return terraauth.NewStdTx(msgs, fee, []terraauth.StdSignature{{PubKey: privKey.PubKey(), Signature: sig}}, "")
+	}
+
+	ctx := context.Background()
+	svc := NewTransactionService(httpclient.New(cdc, server.URL), WithSignerCheck())
+
+	_, err = svc.BroadcastTx(ctx, signedTx("bombay-12", 7, 4), types.ModeSync)
+	assert.True(t, errors.Is(err, ErrStaleSequence))
+
+	_, err = svc.BroadcastTx(ctx, signedTx("bombay-12", 8, 5), types.ModeSync)
+	assert.True(t, errors.Is(err, ErrWrongAccountNumber))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&broadcasts))
+
+	_, err = svc.BroadcastTx(ctx, signedTx("bombay-12", 7, 5), types.ModeSync)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&broadcasts))
+}
+
+func TestBroadcastTxDefaultMode(t *testing.T) {
+	var modes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Mode string `json:"mode"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		modes = append(modes, req.Mode)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"height":"0","txhash":"ABCD","code":0}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	svc := NewTransactionService(httpclient.New(nil, server.URL), WithDefaultBroadcastMode(types.ModeBlock))
+
+	_, err := svc.BroadcastTxDefault(ctx, terraauth.StdTx{})
+	assert.NoError(t, err)
+	_, err = svc.BroadcastTx(ctx, terraauth.StdTx{}, "")
+	assert.NoError(t, err)
+	_, err = svc.BroadcastTx(ctx, terraauth.StdTx{}, types.ModeAsync)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"block", "block", "async"}, modes)
+
+	svc = NewTransactionService(httpclient.New(nil, server.URL), WithDefaultBroadcastMode("commit"))
+	_, err = svc.BroadcastTxDefault(ctx, terraauth.StdTx{})
+	assert.NoError(t, err)
+	_, err = svc.BroadcastTx(ctx, terraauth.StdTx{}, "")
+	assert.NoError(t, err)
+	_, err = svc.BroadcastTx(ctx, terraauth.StdTx{}, "commit")
+	assert.EqualError(t, err, `invalid broadcast mode "commit"`)
+	assert.Equal(t, []string{"block", "block", "async", "sync", "sync"}, modes)
+}
+
+func TestGetTxByHashRPCFallback(t *testing.T) {
+	cdc := terraapp.MakeCodec()
+	tx := terraauth.NewStdTx(nil, terraauth.StdFee{Gas: 200000}, nil, "rpc")
+	txBytes, err := cdc.MarshalBinaryLengthPrefixed(tx)
+	assert.NoError(t, err)
+
+	lcd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blocks/5" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"block_id":{},"block":{"header":{"height":"5","time":"2021-01-01T00:00:00Z"}}}`))
+	}))
+	defer lcd.Close()
+
+	rpc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tx", r.URL.Path)
+		assert.Equal(t, "0xabcd", r.URL.Query().Get("hash"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":-1,"result":{
+			"hash":"ABCD","height":"5","index":0,
+			"tx_result":{"code":0,"data":null,"log":"[]","info":"","gasWanted":"200000","gasUsed":"50000","events":[],"codespace":""},
+			"tx":"%s"
+		}}`, base64.StdEncoding.EncodeToString(txBytes))))
+	}))
+	defer rpc.Close()
+
+	svc := NewTransactionService(
+		httpclient.New(cdc, lcd.URL),
+		WithRPCFallback(rpcclient.New(httpclient.New(cdc, rpc.URL))),
+	)
+	resp, err := svc.GetTxByHash(context.Background(), "ABCD")
+	assert.NoError(t, err)
+	assert.Equal(t, "ABCD", resp.TxHash)
+	assert.Equal(t, int64(5), resp.Height)
+	assert.Equal(t, int64(50000), resp.GasUsed)
+	assert.Equal(t, "2021-01-01T00:00:00Z", resp.Timestamp)
+
+	decoded, err := DecodedTx(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "rpc", decoded.Memo)
+
+	_, err = NewTransactionService(httpclient.New(cdc, lcd.URL)).GetTxByHash(context.Background(), "ABCD")
+	assert.True(t, httpclient.IsNotFound(err))
+}
+
+func TestEstimateFeeFull(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/txs/estimate_fee": `{"height":"1234","result":{
+			"fee":{"amount":[{"denom":"uluna","amount":"3600"}],"gas":"24000"},
+			"gas_estimate":"20000"
+		}}`,
+	})
+	defer closer()
+
+	gasPrices := cosmostypes.DecCoins{{
+		Denom:  "uluna",
+		Amount: cosmostypes.NewDecWithPrec(15, 2),
+	}}
+	svc := NewTransactionService(client)
+	resp, err := svc.EstimateFeeFull(context.Background(), "", terraauth.StdSignMsg{ChainID: "bombay-12"}, "1.2", gasPrices)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1234), resp.Height)
+	assert.Equal(t, uint64(20000), resp.GasEstimate)
+	assert.Equal(t, uint64(24000), resp.Fee.Gas)
+	assert.Equal(t, "3600uluna", resp.Fee.Amount.String())
+
+	fee, err := svc.EstimateFee(context.Background(), "", terraauth.StdSignMsg{ChainID: "bombay-12"}, "1.2", gasPrices)
+	assert.NoError(t, err)
+	assert.Equal(t, resp.Fee, fee)
+}
+
+func TestQueryFailedTxs(t *testing.T) {
+	sender := mockAddress(1)
+
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"total_count":"3","count":"3","page_number":"1","page_total":"1","limit":"30",
+			"txs":[
+				{"height":"100","txhash":"OK","code":0,"raw_log":"[]"},
+				{"height":"101","txhash":"OUT_OF_GAS","code":11,"codespace":"sdk","raw_log":"out of gas"},
+				{"height":"102","txhash":"CONTRACT","code":4,"codespace":"wasm","raw_log":"execute wasm contract failed"}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	resp, err := NewTransactionService(httpclient.New(nil, server.URL)).QueryFailedTxs(context.Background(), sender, 1, 30)
+	assert.NoError(t, err)
+	assert.Equal(t, sender.String(), query.Get("message.sender"))
+	assert.Equal(t, "1", query.Get("page"))
+	assert.Equal(t, "30", query.Get("limit"))
+
+	assert.Len(t, resp.Txs, 2)
+	assert.Equal(t, "OUT_OF_GAS", resp.Txs[0].TxHash)
+	assert.Equal(t, "out of gas", resp.Txs[0].RawLog)
+	assert.Equal(t, "CONTRACT", resp.Txs[1].TxHash)
+	assert.Equal(t, "execute wasm contract failed", resp.Txs[1].RawLog)
+	assert.Equal(t, int64(2), resp.Count.Int64())
+	assert.Equal(t, int64(3), resp.TotalCount.Int64())
+}
+
+func TestBroadcastTxV1Beta1(t *testing.T) {
+	var (
+		path string
+		req  struct {
+			TxBytes string `json:"tx_bytes"`
+			Mode    string `json:"mode"`
+		}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"tx_response":{
+			"height":"0","txhash":"ABCD","codespace":"","code":0,"data":"","raw_log":"[]","logs":[],
+			"info":"","gas_wanted":"200000","gas_used":"0","tx":null,"timestamp":""
+		}}`))
+	}))
+	defer server.Close()
+
+	tx := terraauth.StdTx{Memo: "v1beta1"}
+	encoder := func(encoded terraauth.StdTx) ([]byte, error) {
+		assert.Equal(t, tx.Memo, encoded.Memo)
+		return []byte("tx raw"), nil
+	}
+	svc := NewTransactionService(httpclient.New(nil, server.URL), WithV1Beta1Broadcast(encoder))
+
+	resp, err := svc.BroadcastTx(context.Background(), tx, types.ModeBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, "/cosmos/tx/v1beta1/txs", path)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("tx raw")), req.TxBytes)
+	assert.Equal(t, "BROADCAST_MODE_BLOCK", req.Mode)
+	assert.Equal(t, "ABCD", resp.TxHash)
+	assert.Equal(t, int64(200000), resp.GasWanted)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cawabunga/terra.go/httpclient"
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	terraauth "github.com/terra-project/core/x/auth"
+)
+
+// TxEncoder encodes a signed tx as the protobuf TxRaw bytes the v1beta1 endpoints take.
+type TxEncoder func(tx terraauth.StdTx) ([]byte, error)
+
+var v1beta1BroadcastModes = map[types.BroadcastMode]string{
+	types.ModeBlock: "BROADCAST_MODE_BLOCK",
+	types.ModeSync:  "BROADCAST_MODE_SYNC",
+	types.ModeAsync: "BROADCAST_MODE_ASYNC",
+}
+
+func (svc transactionService) broadcastV1Beta1(
+	ctx context.Context,
+	tx terraauth.StdTx,
+	mode types.BroadcastMode,
+) (cosmostypes.TxResponse, error) {
+	v1beta1Mode, ok := v1beta1BroadcastModes[mode]
+	if !ok {
+		return cosmostypes.TxResponse{}, errors.Errorf("invalid broadcast mode %q", mode)
+	}
+	txBytes, err := svc.txEncoder(tx)
+	if err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrap(err, "encode tx")
+	}
+
+	rawPayloadBody, err := json.Marshal(v1beta1BroadcastReq{TxBytes: txBytes, Mode: v1beta1Mode})
+	if err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrap(err, "marshal request body")
+	}
+
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodPost,
+		Path:    "/cosmos/tx/v1beta1/txs",
+		Body:    bytes.NewReader(rawPayloadBody),
+
+		Idempotent: true,
+	}
+
+	var body struct {
+		TxResponse v1beta1TxResponse `json:"tx_response"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return cosmostypes.TxResponse{}, errors.Wrap(err, "request json")
+	}
+
+	r := body.TxResponse
+	resp := cosmostypes.TxResponse{
+		Height:    r.Height,
+		TxHash:    r.TxHash,
+		Codespace: r.Codespace,
+		Code:      r.Code,
+		Data:      r.Data,
+		RawLog:    r.RawLog,
+		Info:      r.Info,
+		GasWanted: r.GasWanted,
+		GasUsed:   r.GasUsed,
+		Timestamp: r.Timestamp,
+	}
+	if resp.Code != abcitypes.CodeTypeOK {
+		return resp, errors.New(resp.RawLog)
+	}
+	resp.Logs, _ = cosmostypes.ParseABCILogs(resp.RawLog)
+	return resp, nil
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	cosmostypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
+	terratypes "github.com/terra-project/core/types"
+	terraassets "github.com/terra-project/core/types/assets"
+	terratreasury "github.com/terra-project/core/x/treasury"
 )
 
 //go:generate mockgen -destination ../../../test/mocks/terra/service/service_treasury.go . TreasuryService
@@ -17,15 +20,37 @@ type TreasuryService interface {
 	CalculateTax(ctx context.Context, coin cosmostypes.Coin) (cosmostypes.Int, error)
 	GetTaxRate(ctx context.Context) (GetTaxRateResponse, error)
 	GetTaxCap(ctx context.Context, denom string) (GetTaxCapResponse, error)
+	GetTaxProceeds(ctx context.Context) (GetTaxProceedsResponse, error)
+	GetSeigniorageProceeds(ctx context.Context) (GetSeigniorageProceedsResponse, error)
+	GetRewardWeight(ctx context.Context) (GetRewardWeightResponse, error)
+	GetParams(ctx context.Context) (terratreasury.Params, error)
+	GetCurrentEpoch(ctx context.Context) (int64, error)
+	GetEpochInfo(ctx context.Context) (EpochInfo, error)
+	GetTaxRewardsLast(ctx context.Context) (cosmostypes.Dec, error)
+	GetSeigniorageRewardsLast(ctx context.Context) (cosmostypes.Dec, error)
+	GetIndicators(ctx context.Context) (TreasuryIndicators, error)
 }
 
+// EpochLength is the number of blocks between treasury updates. It's a chain constant
+// rather than a param; the window params are counted in epochs of this length.
+const EpochLength = int64(terratypes.BlocksPerWeek)
+
 type treasuryService struct {
-	codec  *codec.Codec
-	client httpclient.Client
+	codec      *codec.Codec
+	client     httpclient.Client
+	oracle     OracleService
+	staking    StakingService
+	tendermint TendermintService
 }
 
 func NewTreasuryService(client httpclient.Client) TreasuryService {
-	return treasuryService{codec: client.Codec(), client: client}
+	return treasuryService{
+		codec:      client.Codec(),
+		client:     client,
+		oracle:     NewOracleService(client),
+		staking:    NewStakingService(client),
+		tendermint: NewTendermintService(client),
+	}
 }
 
 func (svc treasuryService) CalculateTax(ctx context.Context, coin cosmostypes.Coin) (cosmostypes.Int, error) {
@@ -87,3 +112,244 @@ func (svc treasuryService) GetTaxCap(ctx context.Context, denom string) (GetTaxC
 		TaxCap: body.Result,
 	}, nil
 }
+
+// GetTaxProceeds returns the tax proceeds of the current epoch along with the height they were
+// read at, in the response type GetTaxRate and GetTaxCap use.
+func (svc treasuryService) GetTaxProceeds(ctx context.Context) (GetTaxProceedsResponse, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/treasury/tax_proceeds",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint  `json:"height"`
+		Result cosmostypes.Coins `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return GetTaxProceedsResponse{}, errors.Wrap(err, "request json")
+	}
+	return GetTaxProceedsResponse{
+		Height:      body.Height.Uint64(),
+		TaxProceeds: body.Result,
+	}, nil
+}
+
+// GetSeigniorageProceeds returns the seigniorage of the current epoch along with the height it was read at.
+func (svc treasuryService) GetSeigniorageProceeds(ctx context.Context) (GetSeigniorageProceedsResponse, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/treasury/seigniorage_proceeds",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint `json:"height"`
+		Result cosmostypes.Int  `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return GetSeigniorageProceedsResponse{}, errors.Wrap(err, "request json")
+	}
+	return GetSeigniorageProceedsResponse{
+		Height:              body.Height.Uint64(),
+		SeigniorageProceeds: body.Result,
+	}, nil
+}
+
+// GetRewardWeight returns the share of seigniorage given to stakers along with the height it was read at.
+func (svc treasuryService) GetRewardWeight(ctx context.Context) (GetRewardWeightResponse, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/treasury/reward_weight",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint `json:"height"`
+		Result cosmostypes.Dec  `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return GetRewardWeightResponse{}, errors.Wrap(err, "request json")
+	}
+	return GetRewardWeightResponse{
+		Height:       body.Height.Uint64(),
+		RewardWeight: body.Result,
+	}, nil
+}
+
+func (svc treasuryService) GetParams(ctx context.Context) (terratreasury.Params, error) {
+	var payload = httpclient.RequestPayload{
+		Context: ctx,
+		Method:  http.MethodGet,
+		Path:    "/treasury/parameters",
+	}
+
+	var body struct {
+		Height cosmostypes.Uint     `json:"height"`
+		Result terratreasury.Params `json:"result"`
+	}
+	if err := svc.client.RequestJSON(payload, &body); err != nil {
+		return terratreasury.Params{}, errors.Wrap(err, "request json")
+	}
+	return body.Result, nil
+}
+
+func (svc treasuryService) GetCurrentEpoch(ctx context.Context) (int64, error) {
+	info, err := svc.GetEpochInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return info.Epoch, nil
+}
+
+// GetEpochInfo reports the epoch of the latest block and how far away the next treasury update is.
+func (svc treasuryService) GetEpochInfo(ctx context.Context) (EpochInfo, error) {
+	_, block, err := svc.tendermint.GetBlockByHeight(ctx, nil)
+	if err != nil {
+		return EpochInfo{}, errors.Wrap(err, "fetch latest block")
+	}
+	return epochInfoAt(block.Height), nil
+}
+
+// epochInfoAt mirrors the treasury EndBlocker, which updates on the last block of each epoch.
+func epochInfoAt(height int64) EpochInfo {
+	return EpochInfo{
+		Height:          height,
+		Epoch:           height / EpochLength,
+		BlocksRemaining: EpochLength - (height+1)%EpochLength,
+	}
+}
+
+// GetTaxRewardsLast returns the tax rewards (TR) of the last completed epoch in µSDR.
+func (svc treasuryService) GetTaxRewardsLast(ctx context.Context) (cosmostypes.Dec, error) {
+	ctx, _, err := svc.atLastEpoch(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, err
+	}
+	rates, err := svc.oracle.GetExchangeRates(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch exchange rates")
+	}
+	return svc.taxRewards(ctx, rates)
+}
+
+// GetSeigniorageRewardsLast returns the seigniorage rewards (SR) of the last completed epoch in µSDR.
+func (svc treasuryService) GetSeigniorageRewardsLast(ctx context.Context) (cosmostypes.Dec, error) {
+	ctx, _, err := svc.atLastEpoch(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, err
+	}
+	rates, err := svc.oracle.GetExchangeRates(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch exchange rates")
+	}
+	return svc.seigniorageRewards(ctx, rates)
+}
+
+// GetIndicators returns the monetary indicators of the last completed epoch the way the
+// treasury computes them on its update. It reads state as of the block before that update,
+// after which the epoch's proceeds are reset, so older epochs need an archive node.
+func (svc treasuryService) GetIndicators(ctx context.Context) (TreasuryIndicators, error) {
+	ctx, info, err := svc.atLastEpoch(ctx)
+	if err != nil {
+		return TreasuryIndicators{}, err
+	}
+	rates, err := svc.oracle.GetExchangeRates(ctx)
+	if err != nil {
+		return TreasuryIndicators{}, errors.Wrap(err, "fetch exchange rates")
+	}
+
+	indicators := TreasuryIndicators{
+		Epoch:  info.Epoch,
+		Height: info.Height,
+		TRL:    cosmostypes.ZeroDec(),
+		SMR:    cosmostypes.ZeroDec(),
+	}
+	if indicators.TaxRewards, err = svc.taxRewards(ctx, rates); err != nil {
+		return TreasuryIndicators{}, err
+	}
+	if indicators.SeigniorageRewards, err = svc.seigniorageRewards(ctx, rates); err != nil {
+		return TreasuryIndicators{}, err
+	}
+	pool, err := svc.staking.GetPool(ctx)
+	if err != nil {
+		return TreasuryIndicators{}, errors.Wrap(err, "fetch staking pool")
+	}
+	indicators.TotalStakedLuna = pool.BondedTokens
+
+	if indicators.TotalStakedLuna.IsPositive() {
+		indicators.TRL = indicators.TaxRewards.QuoInt(indicators.TotalStakedLuna)
+	}
+	if mining := indicators.TaxRewards.Add(indicators.SeigniorageRewards); mining.IsPositive() {
+		indicators.SMR = indicators.SeigniorageRewards.Quo(mining)
+	}
+	return indicators, nil
+}
+
+// atLastEpoch scopes ctx to the block before the latest treasury update.
+func (svc treasuryService) atLastEpoch(ctx context.Context) (context.Context, EpochInfo, error) {
+	_, block, err := svc.tendermint.GetBlockByHeight(ctx, nil)
+	if err != nil {
+		return nil, EpochInfo{}, errors.Wrap(err, "fetch latest block")
+	}
+
+	update := (block.Height+1)/EpochLength*EpochLength - 1
+	if update < 1 {
+		return nil, EpochInfo{}, errors.Wrapf(ErrNoCompletedEpoch, "latest height %d", block.Height)
+	}
+	info := epochInfoAt(update - 1)
+	return httpclient.AtHeight(ctx, info.Height), info, nil
+}
+
+func (svc treasuryService) taxRewards(ctx context.Context, rates cosmostypes.DecCoins) (cosmostypes.Dec, error) {
+	proceeds, err := svc.GetTaxProceeds(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch tax proceeds")
+	}
+	return alignCoins(cosmostypes.NewDecCoinsFromCoins(proceeds.TaxProceeds...), rates, terraassets.MicroSDRDenom), nil
+}
+
+// seigniorageRewards is the share of the seigniorage the reward weight gives to stakers.
+func (svc treasuryService) seigniorageRewards(ctx context.Context, rates cosmostypes.DecCoins) (cosmostypes.Dec, error) {
+	seigniorage, err := svc.GetSeigniorageProceeds(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch seigniorage proceeds")
+	}
+	weight, err := svc.GetRewardWeight(ctx)
+	if err != nil {
+		return cosmostypes.Dec{}, errors.Wrap(err, "fetch reward weight")
+	}
+
+	rewards := cosmostypes.NewDecCoinFromDec(
+		terraassets.MicroLunaDenom,
+		seigniorage.SeigniorageProceeds.ToDec().Mul(weight.RewardWeight),
+	)
+	return alignCoins(cosmostypes.DecCoins{rewards}, rates, terraassets.MicroSDRDenom), nil
+}
+
+// alignCoins values coins in denom at rates, quoted in denom per LUNA, as the treasury keeper does.
+// Like the keeper, it skips denoms without a rate.
+func alignCoins(coins, rates cosmostypes.DecCoins, denom string) cosmostypes.Dec {
+	rate := func(d string) cosmostypes.Dec {
+		if d == terraassets.MicroLunaDenom {
+			return cosmostypes.OneDec()
+		}
+		return rates.AmountOf(d)
+	}
+
+	target := rate(denom)
+	total := cosmostypes.ZeroDec()
+	if !target.IsPositive() {
+		return total
+	}
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			total = total.Add(coin.Amount)
+			continue
+		}
+		if r := rate(coin.Denom); r.IsPositive() {
+			total = total.Add(coin.Amount.Mul(target).Quo(r))
+		}
+	}
+	return total
+}
@@ -11,3 +11,39 @@ type GetTaxCapResponse struct {
 	Height uint64          `json:"height"`
 	TaxCap cosmostypes.Int `json:"tax_cap"`
 }
+
+type GetTaxProceedsResponse struct {
+	Height      uint64            `json:"height"`
+	TaxProceeds cosmostypes.Coins `json:"tax_proceeds"`
+}
+
+type GetSeigniorageProceedsResponse struct {
+	Height              uint64          `json:"height"`
+	SeigniorageProceeds cosmostypes.Int `json:"seigniorage_proceeds"`
+}
+
+type GetRewardWeightResponse struct {
+	Height       uint64          `json:"height"`
+	RewardWeight cosmostypes.Dec `json:"reward_weight"`
+}
+
+type EpochInfo struct {
+	Height int64 `json:"height"`
+	Epoch  int64 `json:"epoch"`
+	// BlocksRemaining is the number of blocks until the next treasury update, which is 1 when the next block runs it.
+	BlocksRemaining int64 `json:"blocks_remaining"`
+}
+
+// TreasuryIndicators are the monetary indicators of an epoch. TaxRewards and SeigniorageRewards
+// are in µSDR, TotalStakedLuna in µLUNA.
+type TreasuryIndicators struct {
+	Epoch              int64           `json:"epoch"`
+	Height             int64           `json:"height"`
+	TaxRewards         cosmostypes.Dec `json:"tax_rewards"`
+	SeigniorageRewards cosmostypes.Dec `json:"seigniorage_rewards"`
+	TotalStakedLuna    cosmostypes.Int `json:"total_staked_luna"`
+	// TRL is the tax rewards per staked LUNA.
+	TRL cosmostypes.Dec `json:"trl"`
+	// SMR is the seigniorage share of the mining rewards, SR / (TR + SR).
+	SMR cosmostypes.Dec `json:"smr"`
+}
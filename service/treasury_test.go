@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cawabunga/terra.go/httpclient"
+
+	"github.com/tj/assert"
+)
+
+func TestTreasuryProceeds(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := newMockClient(map[string]string{
+		"/treasury/tax_proceeds":         `{"height":"100","result":[{"denom":"ukrw","amount":"2000"},{"denom":"uusd","amount":"1000"}]}`,
+		"/treasury/seigniorage_proceeds": `{"height":"100","result":"123456789"}`,
+	})
+	defer closer()
+	svc := NewTreasuryService(client)
+
+	taxProceeds, err := svc.GetTaxProceeds(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), taxProceeds.Height)
+	assert.Equal(t, "2000ukrw,1000uusd", taxProceeds.TaxProceeds.String())
+
+	seigniorage, err := svc.GetSeigniorageProceeds(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), seigniorage.Height)
+	assert.Equal(t, "123456789", seigniorage.SeigniorageProceeds.String())
+}
+
+func TestTreasuryRewardWeightAndParams(t *testing.T) {
+	ctx := context.Background()
+
+	client, closer := newMockClient(map[string]string{
+		"/treasury/reward_weight": `{"height":"100","result":"0.050000000000000000"}`,
+		"/treasury/parameters": `{"height":"100","result":{
+			"tax_policy":{"rate_min":"0.000500000000000000","rate_max":"0.010000000000000000","cap":{"denom":"usdr","amount":"1000000"},"change_rate_max":"0.000250000000000000"},
+			"reward_policy":{"rate_min":"0.050000000000000000","rate_max":"0.900000000000000000","cap":{"denom":"unused","amount":"0"},"change_rate_max":"0.025000000000000000"},
+			"seigniorage_burden_target":"0.670000000000000000",
+			"mining_increment":"1.070000000000000000",
+			"window_short":"4",
+			"window_long":"52",
+			"window_probation":"18"
+		}}`,
+	})
+	defer closer()
+	svc := NewTreasuryService(client)
+
+	rewardWeight, err := svc.GetRewardWeight(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.050000000000000000", rewardWeight.RewardWeight.String())
+
+	params, err := svc.GetParams(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.010000000000000000", params.TaxPolicy.RateMax.String())
+	assert.Equal(t, "usdr", params.TaxPolicy.Cap.Denom)
+	assert.Equal(t, "0.900000000000000000", params.RewardPolicy.RateMax.String())
+	assert.Equal(t, int64(4), params.WindowShort)
+	assert.Equal(t, int64(52), params.WindowLong)
+}
+
+func TestTreasuryEpoch(t *testing.T) {
+	client, closer := newMockClient(map[string]string{
+		"/blocks/latest": `{"block_id":{},"block":{"header":{"height":"403100"}}}`,
+	})
+	defer closer()
+
+	info, err := NewTreasuryService(client).GetEpochInfo(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, EpochInfo{Height: 403100, Epoch: 3, BlocksRemaining: 99}, info)
+
+	// the last block of an epoch runs the update, so the next one is a full epoch away
+	assert.Equal(t, EpochInfo{Height: 100799, Epoch: 0, BlocksRemaining: 100800}, epochInfoAt(100799))
+	assert.Equal(t, EpochInfo{Height: 100798, Epoch: 0, BlocksRemaining: 1}, epochInfoAt(100798))
+}
+
+func TestTreasuryIndicators(t *testing.T) {
+	ctx := context.Background()
+
+	// the last update of epoch 2 ran at 302399, so state is read at the block before
+	routes := map[string]string{
+		"/treasury/tax_proceeds":         `{"height":"302398","result":[{"denom":"ukrw","amount":"2000"},{"denom":"usdr","amount":"100"},{"denom":"ueur","amount":"7"}]}`,
+		"/treasury/seigniorage_proceeds": `{"height":"302398","result":"1000"}`,
+		"/treasury/reward_weight":        `{"height":"302398","result":"0.100000000000000000"}`,
+		"/oracle/denoms/exchange_rates": `{"height":"302398","result":[
+			{"denom":"ukrw","amount":"1000.000000000000000000"},
+			{"denom":"usdr","amount":"0.500000000000000000"}
+		]}`,
+		"/staking/pool": `{"height":"302398","result":{"not_bonded_tokens":"10","bonded_tokens":"1010"}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/blocks/latest" {
+			_, _ = w.Write([]byte(`{"block_id":{},"block":{"header":{"height":"403100"}}}`))
+			return
+		}
+		body, ok := routes[r.URL.Path]
+		if !ok || r.URL.Query().Get("height") != "302398" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	svc := NewTreasuryService(httpclient.New(nil, server.URL))
+
+	// ukrw 2000 / 1000 × 0.5 + usdr 100, ueur has no rate
+	taxRewards, err := svc.GetTaxRewardsLast(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "101.000000000000000000", taxRewards.String())
+
+	// uluna 1000 × 0.1 × 0.5
+	seigniorageRewards, err := svc.GetSeigniorageRewardsLast(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "50.000000000000000000", seigniorageRewards.String())
+
+	indicators, err := svc.GetIndicators(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), indicators.Epoch)
+	assert.Equal(t, int64(302398), indicators.Height)
+	assert.Equal(t, "101.000000000000000000", indicators.TaxRewards.String())
+	assert.Equal(t, "50.000000000000000000", indicators.SeigniorageRewards.String())
+	assert.Equal(t, "1010", indicators.TotalStakedLuna.String())
+	assert.Equal(t, "0.100000000000000000", indicators.TRL.String())
+	assert.Equal(t, "0.331125827814569536", indicators.SMR.String())
+}
@@ -0,0 +1,28 @@
+package terra
+
+import (
+	"github.com/cawabunga/terra.go/types"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// NewCancelUnbonding builds a msg delegating amount of the unbonding entry created at
+// creationHeight back to validator, see types.MsgCancelUnbondingDelegation.
+func NewCancelUnbonding(
+	delegator cosmostypes.AccAddress,
+	validator cosmostypes.ValAddress,
+	amount cosmostypes.Coin,
+	creationHeight int64,
+) (types.MsgCancelUnbondingDelegation, error) {
+	msg := types.MsgCancelUnbondingDelegation{
+		DelegatorAddress: delegator,
+		ValidatorAddress: validator,
+		Amount:           amount,
+		CreationHeight:   creationHeight,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return types.MsgCancelUnbondingDelegation{}, errors.Wrap(err, "validate msg")
+	}
+	return msg, nil
+}
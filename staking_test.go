@@ -0,0 +1,39 @@
+package terra
+
+import (
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	terraauth "github.com/terra-project/core/x/auth"
+	"github.com/tj/assert"
+)
+
+func TestNewCancelUnbonding(t *testing.T) {
+	delegator := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	validator := cosmostypes.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	amount := cosmostypes.NewInt64Coin("uluna", 1000000)
+
+	msg, err := NewCancelUnbonding(delegator, validator, amount, 4200)
+	assert.NoError(t, err)
+	assert.NoError(t, msg.ValidateBasic())
+	assert.Equal(t, int64(4200), msg.CreationHeight)
+	assert.Equal(t, []cosmostypes.AccAddress{delegator}, msg.GetSigners())
+
+	signMsg := terraauth.StdSignMsg{ChainID: "bombay-12", Msgs: []cosmostypes.Msg{msg}}
+	assert.Contains(t, string(signMsg.Bytes()), `"creation_height":"4200"`)
+	assert.Contains(t, string(signMsg.Bytes()), `"type":"cosmos-sdk/MsgCancelUnbondingDelegation"`)
+
+	_, err = NewCancelUnbonding(delegator, validator, amount, 0)
+	assert.Error(t, err)
+	_, err = NewCancelUnbonding(delegator, validator, cosmostypes.NewInt64Coin("uluna", 0), 4200)
+	assert.Error(t, err)
+
+	tx := terraauth.NewStdTx([]cosmostypes.Msg{msg}, terraauth.StdFee{Gas: 200000}, nil, "")
+	cdc := MakeCodec()
+	bz, err := cdc.MarshalJSON(tx)
+	assert.NoError(t, err)
+	var decoded terraauth.StdTx
+	assert.NoError(t, cdc.UnmarshalJSON(bz, &decoded))
+	assert.Equal(t, msg, decoded.Msgs[0])
+}
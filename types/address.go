@@ -0,0 +1,59 @@
+package types
+
+import (
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/bech32"
+	terratypes "github.com/terra-project/core/types"
+)
+
+// AddressPrefix is a set of bech32 prefixes, for forks and networks that don't use terra/terravaloper.
+type AddressPrefix struct {
+	Account   string `json:"account"`
+	Validator string `json:"validator"`
+	Consensus string `json:"consensus"`
+}
+
+var TerraAddressPrefix = AddressPrefix{
+	Account:   terratypes.Bech32PrefixAccAddr,
+	Validator: terratypes.Bech32PrefixValAddr,
+	Consensus: terratypes.Bech32PrefixConsAddr,
+}
+
+func (p AddressPrefix) FormatAccAddress(addr cosmostypes.AccAddress) (string, error) {
+	return bech32.ConvertAndEncode(p.Account, addr)
+}
+
+func (p AddressPrefix) FormatValAddress(addr cosmostypes.ValAddress) (string, error) {
+	return bech32.ConvertAndEncode(p.Validator, addr)
+}
+
+func (p AddressPrefix) FormatConsAddress(addr cosmostypes.ConsAddress) (string, error) {
+	return bech32.ConvertAndEncode(p.Consensus, addr)
+}
+
+func (p AddressPrefix) ParseAccAddress(address string) (cosmostypes.AccAddress, error) {
+	bz, err := parseBech32(address, p.Account)
+	return cosmostypes.AccAddress(bz), err
+}
+
+func (p AddressPrefix) ParseValAddress(address string) (cosmostypes.ValAddress, error) {
+	bz, err := parseBech32(address, p.Validator)
+	return cosmostypes.ValAddress(bz), err
+}
+
+func (p AddressPrefix) ParseConsAddress(address string) (cosmostypes.ConsAddress, error) {
+	bz, err := parseBech32(address, p.Consensus)
+	return cosmostypes.ConsAddress(bz), err
+}
+
+func parseBech32(address, prefix string) ([]byte, error) {
+	bz, err := cosmostypes.GetFromBech32(address, prefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decode %s with prefix %s", address, prefix)
+	}
+	if err := cosmostypes.VerifyAddressFormat(bz); err != nil {
+		return nil, errors.Wrapf(err, "verify %s", address)
+	}
+	return bz, nil
+}
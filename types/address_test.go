@@ -0,0 +1,41 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddressPrefix(t *testing.T) {
+	Convey("init test", t, func() {
+		prefix := AddressPrefix{Account: "fork", Validator: "forkvaloper", Consensus: "forkvalcons"}
+		addr := cosmostypes.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+		Convey("#FormatAccAddress", func() {
+			formatted, err := prefix.FormatAccAddress(addr)
+			So(err, ShouldBeNil)
+			So(strings.HasPrefix(formatted, "fork1"), ShouldBeTrue)
+
+			parsed, err := prefix.ParseAccAddress(formatted)
+			So(err, ShouldBeNil)
+			So(parsed.Equals(addr), ShouldBeTrue)
+		})
+		Convey("#FormatValAddress", func() {
+			formatted, err := prefix.FormatValAddress(cosmostypes.ValAddress(addr))
+			So(err, ShouldBeNil)
+			So(strings.HasPrefix(formatted, "forkvaloper1"), ShouldBeTrue)
+		})
+		Convey("#ParseAccAddress with other prefix", func() {
+			_, err := prefix.ParseAccAddress(addr.String())
+			So(err, ShouldNotBeNil)
+
+			parsed, err := TerraAddressPrefix.ParseAccAddress(addr.String())
+			So(err, ShouldBeNil)
+			So(parsed.Equals(addr), ShouldBeTrue)
+		})
+	})
+}
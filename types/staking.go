@@ -0,0 +1,59 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	cosmostypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+var _ cosmostypes.Msg = MsgCancelUnbondingDelegation{}
+
+// stakingCodec encodes the sign bytes of the msgs here, built once rather than on every signature.
+var stakingCodec = func() *codec.Codec {
+	cdc := codec.New()
+	cdc.RegisterConcrete(MsgCancelUnbondingDelegation{}, "cosmos-sdk/MsgCancelUnbondingDelegation", nil)
+	return cdc
+}()
+
+// MsgCancelUnbondingDelegation delegates an unbonding entry back to its validator. It comes with
+// x/staking of cosmos-sdk v0.46, chains running an older version reject it.
+type MsgCancelUnbondingDelegation struct {
+	DelegatorAddress cosmostypes.AccAddress `json:"delegator_address"`
+	ValidatorAddress cosmostypes.ValAddress `json:"validator_address"`
+	Amount           cosmostypes.Coin       `json:"amount"`
+	// CreationHeight is the height the unbonding entry was created at, which identifies it.
+	CreationHeight int64 `json:"creation_height"`
+}
+
+func (m MsgCancelUnbondingDelegation) Route() string {
+	return stakingtypes.RouterKey
+}
+
+func (m MsgCancelUnbondingDelegation) Type() string {
+	return "cancel_unbond"
+}
+
+func (m MsgCancelUnbondingDelegation) ValidateBasic() error {
+	if m.DelegatorAddress.Empty() {
+		return stakingtypes.ErrEmptyDelegatorAddr
+	}
+	if m.ValidatorAddress.Empty() {
+		return stakingtypes.ErrEmptyValidatorAddr
+	}
+	if !m.Amount.IsValid() || !m.Amount.Amount.IsPositive() {
+		return sdkerrors.Wrap(stakingtypes.ErrBadDelegationAmount, "invalid amount")
+	}
+	if m.CreationHeight <= 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid creation height")
+	}
+	return nil
+}
+
+func (m MsgCancelUnbondingDelegation) GetSignBytes() []byte {
+	return cosmostypes.MustSortJSON(stakingCodec.MustMarshalJSON(m))
+}
+
+func (m MsgCancelUnbondingDelegation) GetSigners() []cosmostypes.AccAddress {
+	return []cosmostypes.AccAddress{m.DelegatorAddress}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// blockTimeLayouts are the forms nodes have been seen to emit timestamps in. Fractional seconds of any
+// precision are accepted by each of them.
+var blockTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999 -0700 MST", // time.Time.String
+	"2006-01-02 15:04:05.999999999Z07:00",
+}
+
+// blockTimeLayoutsUTC have no zone, which tendermint means as UTC.
+var blockTimeLayoutsUTC = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// ParseBlockTime parses a block, tx or proposal timestamp. Besides RFC3339 it takes timestamps without a
+// zone, which are read as UTC, and with more than nanosecond precision, which is truncated.
+// Blocks, vesting accounts and proposals hold their times as time.Time, which amino decodes from the
+// RFC3339 the node writes them in; this is for the string timestamps of tx responses.
+func ParseBlockTime(s string) (time.Time, error) {
+	value := truncateFraction(strings.TrimSpace(s))
+	for _, layout := range blockTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	for _, layout := range blockTimeLayoutsUTC {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("unrecognized timestamp %q", s)
+}
+
+// truncateFraction drops fractional second digits past nanoseconds, which time.Parse rejects.
+func truncateFraction(s string) string {
+	dot := strings.IndexByte(s, '.')
+	if dot < 0 {
+		return s
+	}
+	end := dot + 1
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	if end-dot-1 <= 9 {
+		return s
+	}
+	return s[:dot+10] + s[end:]
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseBlockTime(t *testing.T) {
+	Convey("#ParseBlockTime", t, func() {
+		expected := time.Date(2021, 10, 6, 12, 34, 56, 0, time.UTC)
+
+		for _, s := range []string{
+			"2021-10-06T12:34:56Z",
+			"2021-10-06T12:34:56.000Z",
+			"2021-10-06T21:34:56+09:00",
+			"2021-10-06T21:34:56+0900",
+			"2021-10-06T12:34:56",
+			"2021-10-06 12:34:56 +0000 UTC",
+			" 2021-10-06T12:34:56Z\n",
+		} {
+			parsed, err := ParseBlockTime(s)
+			So(err, ShouldBeNil)
+			So(parsed.Equal(expected), ShouldBeTrue)
+		}
+
+		Convey("keeps fractional seconds up to nanoseconds", func() {
+			parsed, err := ParseBlockTime("2021-10-06T12:34:56.123456Z")
+			So(err, ShouldBeNil)
+			So(parsed.Nanosecond(), ShouldEqual, 123456000)
+
+			parsed, err = ParseBlockTime("2021-10-06T12:34:56.123456789Z")
+			So(err, ShouldBeNil)
+			So(parsed.Nanosecond(), ShouldEqual, 123456789)
+
+			parsed, err = ParseBlockTime("2021-10-06T12:34:56.1234567891234Z")
+			So(err, ShouldBeNil)
+			So(parsed.Nanosecond(), ShouldEqual, 123456789)
+		})
+
+		Convey("rejects anything else", func() {
+			_, err := ParseBlockTime("")
+			So(err, ShouldNotBeNil)
+			_, err = ParseBlockTime("1633523696")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
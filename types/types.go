@@ -17,6 +17,10 @@ const (
 	ModeAsync BroadcastMode = "async"
 )
 
+func (m BroadcastMode) Valid() bool {
+	return m == ModeBlock || m == ModeSync || m == ModeAsync
+}
+
 type TokensHuman struct {
 	Addr   cosmostypes.AccAddress `json:"addr"`
 	Amount cosmostypes.Int        `json:"amount"`
@@ -0,0 +1,52 @@
+package types
+
+type WaitKind int
+
+const (
+	WaitKindNone WaitKind = iota
+	WaitKindCheckTx
+	WaitKindCommitted
+	WaitKindConfirmed
+)
+
+// WaitMode says what a broadcast waits for. Every mode but WaitNone broadcasts with ModeSync
+// and polls afterwards, since ModeBlock can time out the HTTP request on slow blocks.
+type WaitMode struct {
+	Kind          WaitKind
+	Confirmations uint64
+
+	// TimeoutHeight stops the wait with service.ErrWaitTimedOut once the chain is past it and
+	// the tx still isn't included. Zero waits until the context is done.
+	// Only the wait stops: a v0.39 StdTx carries no timeout height, so the tx can still be
+	// included afterwards. Before resending, look the tx up by hash, or re-sign it with the same
+	// sequence so that at most one of the two makes it.
+	TimeoutHeight uint64
+}
+
+var (
+	// WaitNone returns right after the tx is sent, without waiting for CheckTx.
+	WaitNone = WaitMode{Kind: WaitKindNone}
+	// WaitCheckTx returns once the tx has passed CheckTx and entered the mempool.
+	WaitCheckTx = WaitMode{Kind: WaitKindCheckTx}
+	// WaitCommitted returns once the tx is included in a block.
+	WaitCommitted = WaitMode{Kind: WaitKindCommitted}
+)
+
+// WaitConfirmed returns once n more blocks have been committed on top of the tx's block.
+func WaitConfirmed(n uint64) WaitMode {
+	return WaitMode{Kind: WaitKindConfirmed, Confirmations: n}
+}
+
+// WithTimeoutHeight returns m that stops waiting once the chain passes height. The tx itself
+// doesn't expire, see TimeoutHeight.
+func (m WaitMode) WithTimeoutHeight(height uint64) WaitMode {
+	m.TimeoutHeight = height
+	return m
+}
+
+func (m WaitMode) BroadcastMode() BroadcastMode {
+	if m.Kind == WaitKindNone {
+		return ModeAsync
+	}
+	return ModeSync
+}